@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/kdimtriCP/gh-inspector/internal/github"
+	"github.com/kdimtriCP/gh-inspector/internal/gomod"
+	"github.com/kdimtriCP/gh-inspector/internal/license"
+	"github.com/kdimtriCP/gh-inspector/internal/scoring"
+)
+
+// githubApp returns the GitHub App credentials for github.com, or nil when the
+// static github_token should be used.
+func githubApp() *github.AppConfig {
+	app := &github.AppConfig{}
+	if err := viper.UnmarshalKey("github_app", app); err != nil || !app.Enabled() {
+		return nil
+	}
+	return app
+}
+
+// hasGitHubCredentials reports whether github.com can be reached with a token,
+// a token pool or a GitHub App, or any configured host with credentials of its
+// own. An invalid hosts section counts, so that configureAnalyzer reports it.
+func hasGitHubCredentials(token string) bool {
+	if token != "" || len(viper.GetStringSlice("github_tokens")) > 0 || githubApp() != nil {
+		return true
+	}
+
+	var hosts []github.HostConfig
+	if err := viper.UnmarshalKey("hosts", &hosts); err != nil {
+		return true
+	}
+	for _, host := range hosts {
+		if host.HasCredentials() {
+			return true
+		}
+	}
+	return false
+}
+
+// resolverClient returns the HTTP client for module lookups, set up with the
+// proxy and CA bundle of the github.com entry in hosts, if any.
+func resolverClient() (*http.Client, error) {
+	var hosts []github.HostConfig
+	if err := viper.UnmarshalKey("hosts", &hosts); err != nil {
+		return nil, fmt.Errorf("invalid hosts configuration: %w", err)
+	}
+
+	config := github.HostConfig{}
+	for _, host := range hosts {
+		if host.Host == "" || strings.EqualFold(host.Host, github.DefaultHost) {
+			config = host
+		}
+	}
+	client, err := config.HTTPClient(gomod.DefaultResolveTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure host %s: %w", github.DefaultHost, err)
+	}
+	return client, nil
+}
+
+func configureAnalyzer(analyzer *github.RepoAnalyzer) error {
+	if app := githubApp(); app != nil {
+		if err := analyzer.AddHost(github.HostConfig{Host: github.DefaultHost, App: app}); err != nil {
+			return fmt.Errorf("failed to configure GitHub App: %w", err)
+		}
+	} else if tokens := viper.GetStringSlice("github_tokens"); len(tokens) > 0 {
+		if err := analyzer.AddHost(github.HostConfig{Host: github.DefaultHost, Tokens: tokens}); err != nil {
+			return fmt.Errorf("failed to configure GitHub token pool: %w", err)
+		}
+	}
+
+	var hosts []github.HostConfig
+	if err := viper.UnmarshalKey("hosts", &hosts); err != nil {
+		return fmt.Errorf("invalid hosts configuration: %w", err)
+	}
+	for _, host := range hosts {
+		if err := analyzer.AddHost(host); err != nil {
+			return fmt.Errorf("failed to configure host %s: %w", host.Host, err)
+		}
+	}
+
+	if batchSize := viper.GetInt("batch_size"); batchSize > 0 {
+		analyzer.SetBatchSize(batchSize)
+	}
+	if workers := viper.GetInt("concurrency"); workers > 0 {
+		analyzer.SetConcurrency(workers)
+	}
+	if days := viper.GetInt("history.window_days"); days > 0 {
+		analyzer.SetHistoryWindow(time.Duration(days) * 24 * time.Hour)
+	}
+	if viper.IsSet("rate_limit.reserve") {
+		analyzer.SetRateLimitReserve(viper.GetInt("rate_limit.reserve"))
+	}
+	analyzer.SetRetryConfig(retryConfig())
+
+	policy := &license.Policy{}
+	if err := viper.UnmarshalKey("license_policy", policy); err != nil {
+		return fmt.Errorf("invalid license policy: %w", err)
+	}
+	if policy.Enabled() {
+		if err := policy.Validate(); err != nil {
+			return err
+		}
+		analyzer.SetLicensePolicy(policy)
+	}
+	return nil
+}
+
+// loadScoringConfig reads the scoring section over the default configuration,
+// so weights it leaves out keep their default instead of dropping to zero.
+func loadScoringConfig() (*scoring.Config, error) {
+	config := scoring.DefaultConfig()
+	if err := viper.UnmarshalKey("scoring", config); err != nil {
+		return nil, fmt.Errorf("invalid scoring configuration: %w", err)
+	}
+	return config, nil
+}
+
+func searchLimit() int {
+	if limit := viper.GetInt("search.default_limit"); limit > 0 {
+		return limit
+	}
+	return github.DefaultSearchLimit
+}
+
+func maxSearchLimit() int {
+	if limit := viper.GetInt("search.max_limit"); limit > 0 {
+		return min(limit, github.MaxSearchResults)
+	}
+	return github.DefaultMaxSearchLimit
+}
+
+func retryConfig() github.RetryConfig {
+	config := github.DefaultRetryConfig()
+	if viper.IsSet("retry.max_attempts") {
+		config.MaxAttempts = viper.GetInt("retry.max_attempts")
+	}
+	if viper.IsSet("retry.initial_backoff") {
+		config.InitialBackoff = viper.GetDuration("retry.initial_backoff")
+	}
+	if viper.IsSet("retry.max_backoff") {
+		config.MaxBackoff = viper.GetDuration("retry.max_backoff")
+	}
+	return config
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdimtriCP/gh-inspector/internal/scoring"
+)
+
+func TestHasGitHubCredentials(t *testing.T) {
+	t.Cleanup(func() { viper.Set("hosts", nil) })
+
+	require.True(t, hasGitHubCredentials("ghp_token"))
+
+	viper.Set("hosts", []map[string]interface{}{{"host": "ghe.corp.local"}})
+	require.False(t, hasGitHubCredentials(""))
+
+	viper.Set("hosts", []map[string]interface{}{
+		{"host": "ghe.corp.local"},
+		{"host": "ghe.example.com", "tokens": []string{"a", "b"}},
+	})
+	require.True(t, hasGitHubCredentials(""), "a host with its own tokens should be enough")
+
+	viper.Set("hosts", []map[string]interface{}{{"host": "ghe.corp.local", "app": map[string]interface{}{"app_id": 1}}})
+	require.True(t, hasGitHubCredentials(""), "a host with a GitHub App should be enough")
+}
+
+func TestScoringConfigFromViper(t *testing.T) {
+	t.Cleanup(func() { viper.Set("scoring", nil) })
+
+	viper.Set("scoring", map[string]interface{}{
+		"weights": map[string]interface{}{
+			"stars":               0.2,
+			"recent_activity":     0.3,
+			"has_code_of_conduct": 0.1,
+			"has_security":        0.05,
+		},
+	})
+
+	config, err := loadScoringConfig()
+	require.NoError(t, err)
+	require.Equal(t, 0.2, config.Weights.Stars)
+	require.Equal(t, 0.3, config.Weights.RecentActivity)
+	require.Equal(t, 0.1, config.Weights.HasCodeOfConduct)
+	require.Equal(t, 0.05, config.Weights.SecurityWeight(), "has_security should weigh the security sub-score")
+	require.Equal(t, scoring.DefaultConfig().Weights.Forks, config.Weights.Forks, "missing weights should keep their default")
+
+	viper.Set("scoring", map[string]interface{}{"weights": "none"})
+	_, err = loadScoringConfig()
+	require.ErrorContains(t, err, "invalid scoring configuration")
+}
+
+func TestResolverClient(t *testing.T) {
+	t.Cleanup(func() { viper.Set("hosts", nil) })
+
+	viper.Set("hosts", []map[string]interface{}{
+		{"host": "ghe.corp.local", "proxy": "http://ghe-proxy.corp.local:3128"},
+		{"host": "github.com", "proxy": "http://proxy.corp.local:3128"},
+	})
+	client, err := resolverClient()
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "https://gopkg.in/yaml.v3?go-get=1", nil)
+	require.NoError(t, err)
+	proxy, err := client.Transport.(*http.Transport).Proxy(req)
+	require.NoError(t, err)
+	require.Equal(t, "http://proxy.corp.local:3128", proxy.String())
+
+	viper.Set("hosts", []map[string]interface{}{{"host": "github.com", "ca_bundle": "missing.pem"}})
+	_, err = resolverClient()
+	require.ErrorContains(t, err, "failed to read CA bundle")
+}
+
+func TestShippedConfigMatchesDefaults(t *testing.T) {
+	shipped := viper.New()
+	shipped.SetConfigFile("../configs/config.yaml")
+	require.NoError(t, shipped.ReadInConfig())
+
+	config := &scoring.Config{}
+	require.NoError(t, shipped.UnmarshalKey("scoring", config))
+	require.Equal(t, scoring.DefaultConfig(), config)
+}
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kdimtriCP/gh-inspector/internal/formatter"
+	"github.com/kdimtriCP/gh-inspector/internal/github"
+	"github.com/kdimtriCP/gh-inspector/internal/gomod"
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+	"github.com/kdimtriCP/gh-inspector/internal/repolist"
+	"github.com/kdimtriCP/gh-inspector/internal/sbom"
+)
+
+var (
+	orgs              []string
+	users             []string
+	includeArchived   bool
+	includeForks      bool
+	discoverLanguages []string
+	discoverTopics    []string
+	minStars          int
+	pushedSince       string
+	searchQuery       string
+	searchLimitFlag   int
+	reposFiles        []string
+	reposFormat       string
+	reposColumn       string
+	goModFile         string
+	goModIndirect     bool
+	sbomFile          string
+)
+
+// codeUnmapped reports dependencies that could not be mapped to a GitHub
+// repository, alongside the repositories that failed analysis.
+const codeUnmapped = "UNMAPPED"
+
+func discoveryRequested() bool {
+	return len(orgs) > 0 || len(users) > 0
+}
+
+func repositoriesRequested() bool {
+	return len(repos) > 0 || len(reposFiles) > 0 || discoveryRequested() || searchQuery != "" || goModFile != "" || sbomFile != ""
+}
+
+// repositorySources are the repositories to score, the dependencies that map
+// to no repository, and the SBOM entries behind each repository.
+type repositorySources struct {
+	targets    []string
+	unmapped   []*formatter.ErrorRecord
+	components map[string][]metrics.SBOMComponent
+}
+
+// collectRepositories gathers the repositories to score from every source
+// given on the command line.
+func collectRepositories(ctx context.Context, stdin io.Reader, analyzer *github.RepoAnalyzer) (*repositorySources, error) {
+	sources := &repositorySources{targets: append([]string(nil), repos...)}
+
+	listed, err := readRepositoryFiles(stdin)
+	if err != nil {
+		return nil, err
+	}
+	sources.targets = append(sources.targets, listed...)
+
+	if discoveryRequested() {
+		discovered, err := discoverRepositories(ctx, analyzer)
+		if err != nil {
+			return nil, err
+		}
+		sources.targets = append(sources.targets, discovered...)
+	}
+
+	if searchQuery != "" {
+		found, err := searchRepositories(ctx, analyzer)
+		if err != nil {
+			return nil, err
+		}
+		sources.targets = append(sources.targets, found...)
+	}
+
+	if goModFile != "" {
+		found, unmapped, err := goModRepositories(ctx)
+		if err != nil {
+			return nil, err
+		}
+		sources.targets = append(sources.targets, found...)
+		sources.unmapped = append(sources.unmapped, unmapped...)
+	}
+
+	if sbomFile != "" {
+		if err := sbomRepositories(ctx, sources); err != nil {
+			return nil, err
+		}
+	}
+
+	return sources, nil
+}
+
+// discoverRepositories lists the repositories of the organizations and users
+// given on the command line that pass the discovery filters.
+func discoverRepositories(ctx context.Context, analyzer *github.RepoAnalyzer) ([]string, error) {
+	filter := github.DiscoveryFilter{
+		IncludeArchived: includeArchived,
+		IncludeForks:    includeForks,
+		Languages:       discoverLanguages,
+		Topics:          discoverTopics,
+		MinStars:        minStars,
+	}
+	if pushedSince != "" {
+		since, err := parseSince(pushedSince, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		filter.PushedSince = since
+	}
+
+	var discovered []string
+	owners := []struct {
+		kind   github.OwnerKind
+		logins []string
+	}{
+		{kind: github.OwnerOrganization, logins: orgs},
+		{kind: github.OwnerUser, logins: users},
+	}
+	for _, owner := range owners {
+		for _, login := range owner.logins {
+			found, err := analyzer.ListRepositories(ctx, owner.kind, login, filter)
+			if err != nil {
+				return nil, err
+			}
+			fmt.Fprintf(os.Stderr, "Found %d repositories for %s\n", len(found), login)
+			discovered = append(discovered, found...)
+		}
+	}
+	return discovered, nil
+}
+
+// readRepositoryFiles reads the --repos-file lists, "-" reading from stdin.
+func readRepositoryFiles(stdin io.Reader) ([]string, error) {
+	opts := repolist.Options{Format: repolist.Format(reposFormat), Column: reposColumn}
+
+	var listed []string
+	readStdin := false
+	for _, path := range reposFiles {
+		if path == repolist.Stdin {
+			if readStdin {
+				return nil, fmt.Errorf("--repos-file - can only be given once")
+			}
+			readStdin = true
+		}
+		found, err := repolist.ReadFile(path, stdin, opts)
+		if err != nil {
+			return nil, err
+		}
+		listed = append(listed, found...)
+	}
+	return listed, nil
+}
+
+// goModRepositories maps the modules required by the --gomod file to GitHub
+// repositories, returning the modules that map to none as error records.
+func goModRepositories(ctx context.Context) ([]string, []*formatter.ErrorRecord, error) {
+	modules, err := gomod.ReadFile(goModFile, goModIndirect)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := resolverClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var found []string
+	var unmapped []*formatter.ErrorRecord
+	for _, resolution := range gomod.NewResolver(client).ResolveAll(ctx, modules) {
+		if resolution.Err != nil {
+			unmapped = append(unmapped, &formatter.ErrorRecord{
+				Repository: resolution.Module.Required,
+				Code:       codeUnmapped,
+				Message:    resolution.Err.Error(),
+			})
+			continue
+		}
+		found = append(found, resolution.Repository)
+	}
+	fmt.Fprintf(os.Stderr, "Mapped %d of %d modules in %s to GitHub repositories\n", len(found), len(modules), goModFile)
+	return found, unmapped, nil
+}
+
+// sbomRepositories maps the components of the --sbom document to GitHub
+// repositories and records which components each repository came from.
+func sbomRepositories(ctx context.Context, sources *repositorySources) error {
+	format, components, err := sbom.ReadFile(sbomFile)
+	if err != nil {
+		return err
+	}
+	client, err := resolverClient()
+	if err != nil {
+		return err
+	}
+
+	sources.components = make(map[string][]metrics.SBOMComponent)
+	mapped := 0
+	for _, resolution := range sbom.NewResolver(gomod.NewResolver(client)).ResolveAll(ctx, components) {
+		if resolution.Err != nil {
+			message := resolution.Err.Error()
+			if ref := resolution.Component.Ref; ref != "" {
+				message = fmt.Sprintf("SBOM entry %s: %s", ref, message)
+			}
+			sources.unmapped = append(sources.unmapped, &formatter.ErrorRecord{
+				Repository: resolution.Component.String(),
+				Code:       codeUnmapped,
+				Message:    message,
+			})
+			continue
+		}
+		mapped++
+		sources.targets = append(sources.targets, resolution.Repository)
+		sources.components[resolution.Repository] = append(sources.components[resolution.Repository], resolution.Component.Entry())
+	}
+	fmt.Fprintf(os.Stderr, "Mapped %d of %d %s components in %s to GitHub repositories\n", mapped, len(components), format, sbomFile)
+	return nil
+}
+
+// searchRepositories runs the --search query, scoring up to --limit results.
+func searchRepositories(ctx context.Context, analyzer *github.RepoAnalyzer) ([]string, error) {
+	limit := searchLimitFlag
+	if limit == 0 {
+		limit = searchLimit()
+	}
+	if maxLimit := maxSearchLimit(); limit < 1 || limit > maxLimit {
+		return nil, fmt.Errorf("--limit must be between 1 and %d (search.max_limit)", maxLimit)
+	}
+
+	found, err := analyzer.SearchRepositories(ctx, searchQuery, limit)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(os.Stderr, "Found %d repositories for search %q\n", len(found), searchQuery)
+	return found, nil
+}
+
+// parseSince accepts a date (2006-01-02), an RFC 3339 timestamp, or an age
+// such as 90d or 720h counted back from now.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --pushed-since %q: expected a date like 2024-01-31 or an age like 90d", value)
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "2024-01-31", want: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{value: "2024-01-31T10:00:00Z", want: time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)},
+		{value: "90d", want: now.AddDate(0, 0, -90)},
+		{value: "36h", want: now.Add(-36 * time.Hour)},
+		{value: "last week", wantErr: true},
+		{value: "-5d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseSince(tt.value, now)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, tt.want.Equal(got), "got %s", got)
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -13,35 +15,41 @@ import (
 	"github.com/kdimtriCP/gh-inspector/internal/formatter"
 	"github.com/kdimtriCP/gh-inspector/internal/github"
 	"github.com/kdimtriCP/gh-inspector/internal/metrics"
-	"github.com/kdimtriCP/gh-inspector/internal/scoring"
+	"github.com/kdimtriCP/gh-inspector/internal/repolist"
 )
 
 var (
 	repos        []string
 	outputFormat string
 	noCache      bool
+	concurrency  int
 )
 
 var scoreCmd = &cobra.Command{
 	Use:   "score",
 	Short: "Score GitHub repositories",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if len(repos) == 0 {
+		if !repositoriesRequested() {
 			return fmt.Errorf("no repositories specified")
 		}
 
 		token := viper.GetString("github_token")
-		if token == "" {
+		if !hasGitHubCredentials(token) {
 			return fmt.Errorf("GitHub token not configured")
 		}
 
-		scoringConfig := &scoring.Config{}
-		if err := viper.UnmarshalKey("scoring", scoringConfig); err != nil {
-			scoringConfig = scoring.DefaultConfig()
+		scoringConfig, err := loadScoringConfig()
+		if err != nil {
+			return err
 		}
 
 		analyzer := github.NewRepoAnalyzer(token, scoringConfig)
-		ctx := context.Background()
+		if err := configureAnalyzer(analyzer); err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
 
 		if viper.GetBool("cache.enabled") && !noCache {
 			cacheDir := viper.GetString("cache.directory")
@@ -64,19 +72,11 @@ var scoreCmd = &cobra.Command{
 			}
 		}
 
-		var allMetrics []*metrics.Repository
-
-		for _, repo := range repos {
-			metrics, err := analyzer.Analyze(ctx, repo)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error analyzing %s: %v\n", repo, err)
-				continue
-			}
-			allMetrics = append(allMetrics, metrics)
-		}
-
-		if len(allMetrics) == 0 {
-			return fmt.Errorf("no repositories could be analyzed")
+		workers := concurrency
+		if workers <= 0 {
+			workers = viper.GetInt("concurrency")
+		} else {
+			analyzer.SetConcurrency(workers)
 		}
 
 		format := outputFormat
@@ -87,18 +87,95 @@ var scoreCmd = &cobra.Command{
 			format = formatter.FormatTable
 		}
 
-		formatter, err := formatter.New(format)
+		out, err := formatter.New(format)
 		if err != nil {
 			return err
 		}
 
-		return formatter.Format(os.Stdout, allMetrics)
+		sources, err := collectRepositories(ctx, cmd.InOrStdin(), analyzer)
+		if err != nil {
+			return err
+		}
+		failures := sources.unmapped
+		if len(sources.targets) == 0 {
+			if len(failures) > 0 {
+				if err := out.FormatErrors(os.Stderr, failures); err != nil {
+					return err
+				}
+			}
+			return fmt.Errorf("no repositories to score")
+		}
+
+		var allMetrics []*metrics.Repository
+
+		for _, result := range github.AnalyzeAll(ctx, analyzer, github.NormalizeRepositories(sources.targets), workers) {
+			if result.Err != nil {
+				failures = append(failures, &formatter.ErrorRecord{
+					Repository: result.Repository,
+					Code:       string(github.ErrorCodeOf(result.Err)),
+					Message:    result.Err.Error(),
+				})
+				continue
+			}
+			result.Metrics.SBOMComponents = sources.components[result.Repository]
+			if result.Metrics.MovedFrom != "" {
+				fmt.Fprintf(os.Stderr, "Warning: %s has moved to %s, update the reference\n", result.Metrics.MovedFrom, result.Metrics.FullName())
+			}
+			allMetrics = append(allMetrics, result.Metrics)
+		}
+
+		if len(failures) > 0 {
+			if err := out.FormatErrors(os.Stderr, failures); err != nil {
+				return err
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("analysis interrupted: %w", err)
+		}
+
+		if len(allMetrics) == 0 {
+			return fmt.Errorf("no repositories could be analyzed")
+		}
+
+		if err := out.Format(os.Stdout, allMetrics); err != nil {
+			return err
+		}
+
+		violations := 0
+		for _, m := range allMetrics {
+			if m.LicenseViolation != "" {
+				violations++
+			}
+		}
+		if violations > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d of %d repositories violate the license policy", violations, len(allMetrics))
+		}
+		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(scoreCmd)
-	scoreCmd.Flags().StringSliceVarP(&repos, "repos", "r", []string{}, "List of GitHub repositories")
+	scoreCmd.Flags().StringSliceVarP(&repos, "repos", "r", []string{}, "List of GitHub repositories (owner/name, repository URL or git@host:owner/name)")
+	scoreCmd.Flags().StringSliceVar(&reposFiles, "repos-file", []string{}, "Read repositories from files, one per line with # comments, a JSON array or CSV; - reads stdin")
+	scoreCmd.Flags().StringVar(&reposFormat, "repos-format", string(repolist.FormatAuto), "Format of --repos-file (auto, text, json, csv); auto goes by file extension and content")
+	scoreCmd.Flags().StringVar(&reposColumn, "repos-column", "", "CSV column or JSON object key holding the repository, by header name or 1-based index (default repository, repo, nameWithOwner, full_name or url)")
+	scoreCmd.Flags().StringVar(&goModFile, "gomod", "", "Score the dependencies required by a go.mod file")
+	scoreCmd.Flags().BoolVar(&goModIndirect, "gomod-indirect", false, "Also score the indirect dependencies of --gomod")
+	scoreCmd.Flags().StringVar(&sbomFile, "sbom", "", "Score the components of a CycloneDX or SPDX JSON SBOM")
+	scoreCmd.Flags().StringSliceVar(&orgs, "org", []string{}, "Score the repositories of these organizations (login, or host/login for other GitHub instances)")
+	scoreCmd.Flags().StringSliceVar(&users, "user", []string{}, "Score the repositories owned by these users (login, or host/login for other GitHub instances)")
+	scoreCmd.Flags().BoolVar(&includeArchived, "include-archived", false, "Include archived repositories when discovering with --org or --user")
+	scoreCmd.Flags().BoolVar(&includeForks, "include-forks", false, "Include forks when discovering with --org or --user")
+	scoreCmd.Flags().StringSliceVar(&discoverLanguages, "language", []string{}, "Only discover repositories with one of these primary languages (filtered client-side after listing every page)")
+	scoreCmd.Flags().StringSliceVar(&discoverTopics, "topic", []string{}, "Only discover repositories with at least one of these topics (filtered client-side after listing every page)")
+	scoreCmd.Flags().IntVar(&minStars, "min-stars", 0, "Only discover repositories with at least this many stars (filtered client-side after listing every page)")
+	scoreCmd.Flags().StringVar(&pushedSince, "pushed-since", "", "Only discover repositories pushed to since a date (2024-01-31) or within an age (90d) (filtered client-side; listing stops at the first older repository)")
+	scoreCmd.Flags().StringVar(&searchQuery, "search", "", "Score the results of a GitHub repository search, e.g. \"topic:http-router language:go stars:>500\"")
+	scoreCmd.Flags().IntVar(&searchLimitFlag, "limit", 0, "Most search results to score (default from search.default_limit)")
 	scoreCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (table, json, json-compact, csv)")
 	scoreCmd.Flags().BoolVar(&noCache, "no-cache", false, "Disable caching")
+	scoreCmd.Flags().IntVarP(&concurrency, "concurrency", "c", 0, "Number of repositories analyzed in parallel (default from config, otherwise 4)")
 }
@@ -13,7 +13,6 @@ import (
 
 	"github.com/kdimtriCP/gh-inspector/internal/cache"
 	"github.com/kdimtriCP/gh-inspector/internal/github"
-	"github.com/kdimtriCP/gh-inspector/internal/scoring"
 	"github.com/kdimtriCP/gh-inspector/internal/server"
 )
 
@@ -46,8 +45,16 @@ func init() {
 
 func runServe(_ *cobra.Command, _ []string) error {
 	token := viper.GetString("github_token")
-	if token == "" || token == "ghp_yourtokenhere" {
-		return fmt.Errorf("GitHub token not configured. Please set github_token in config file or GITHUB_TOKEN environment variable")
+	if token == "ghp_yourtokenhere" {
+		token = ""
+	}
+	if !hasGitHubCredentials(token) {
+		return fmt.Errorf("GitHub token not configured. Please set github_token, github_tokens, github_app or credentials for one of the hosts in config file, or the GITHUB_TOKEN environment variable")
+	}
+
+	scoringConfig, err := loadScoringConfig()
+	if err != nil {
+		return err
 	}
 
 	cacheEnabled := viper.GetBool("cache.enabled")
@@ -56,42 +63,29 @@ func runServe(_ *cobra.Command, _ []string) error {
 
 	var cacheInstance cache.Cache
 	if cacheEnabled {
-		var err error
 		cacheInstance, err = cache.New(cacheDir)
 		if err != nil {
 			fmt.Printf("Warning: failed to initialize cache: %v\n", err)
 		}
 	}
 
-	scoringConfig := &scoring.Config{
-		Weights: scoring.Weights{
-			Stars:            viper.GetFloat64("scoring.weights.stars"),
-			Forks:            viper.GetFloat64("scoring.weights.forks"),
-			RecentActivity:   viper.GetFloat64("scoring.weights.recent_activity"),
-			OpenIssues:       viper.GetFloat64("scoring.weights.open_issues"),
-			OpenPRs:          viper.GetFloat64("scoring.weights.open_prs"),
-			HasLicense:       viper.GetFloat64("scoring.weights.has_license"),
-			HasCICD:          viper.GetFloat64("scoring.weights.has_cicd"),
-			HasContributing:  viper.GetFloat64("scoring.weights.has_contributing"),
-			ReleaseFrequency: viper.GetFloat64("scoring.weights.release_frequency"),
-			HasReadme:        viper.GetFloat64("scoring.weights.has_readme"),
-			HasCodeOfConduct: viper.GetFloat64("scoring.weights.has_code_of_conduct"),
-			HasSecurity:      viper.GetFloat64("scoring.weights.has_security"),
-			Watchers:         viper.GetFloat64("scoring.weights.watchers"),
-		},
-	}
-
 	analyzer := github.NewRepoAnalyzer(token, scoringConfig)
+	if err := configureAnalyzer(analyzer); err != nil {
+		return err
+	}
 	if cacheInstance != nil {
 		analyzer.SetCache(cacheInstance)
 		analyzer.SetCacheTTL(cacheTTL)
 	}
 
 	serverConfig := &server.Config{
-		Port:         port,
-		ReadTimeout:  time.Duration(readTimeout) * time.Second,
-		WriteTimeout: time.Duration(writeTimeout) * time.Second,
-		IdleTimeout:  60 * time.Second,
+		Port:           port,
+		ReadTimeout:    time.Duration(readTimeout) * time.Second,
+		WriteTimeout:   time.Duration(writeTimeout) * time.Second,
+		IdleTimeout:    60 * time.Second,
+		Concurrency:    viper.GetInt("concurrency"),
+		SearchLimit:    searchLimit(),
+		MaxSearchLimit: maxSearchLimit(),
 	}
 
 	srv := server.New(analyzer, serverConfig)
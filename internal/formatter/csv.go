@@ -23,7 +23,7 @@ func (f *CSVFormatter) Format(writer io.Writer, metricsData []*metrics.Repositor
 	}
 
 	for _, m := range metricsData {
-		row := MetricsToRecord(m).Strings()
+		row := MetricsToRecord(m).CSVStrings()
 		if err := w.Write(row); err != nil {
 			return err
 		}
@@ -31,3 +31,20 @@ func (f *CSVFormatter) Format(writer io.Writer, metricsData []*metrics.Repositor
 
 	return nil
 }
+
+func (f *CSVFormatter) FormatErrors(writer io.Writer, errors []*ErrorRecord) error {
+	w := csv.NewWriter(writer)
+	defer w.Flush()
+
+	if err := w.Write(GetErrorRecordHeaders()); err != nil {
+		return err
+	}
+
+	for _, e := range errors {
+		if err := w.Write(e.Strings()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -10,4 +10,5 @@ import (
 
 type Formatter interface {
 	Format(writer io.Writer, metrics []*metrics.Repository) error
+	FormatErrors(writer io.Writer, errors []*ErrorRecord) error
 }
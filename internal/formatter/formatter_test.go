@@ -152,6 +152,7 @@ func TestCSVFormatter(t *testing.T) {
 			OpenIssues:      5,
 			OpenPRs:         2,
 			LastCommitDate:  time.Time{},
+			WeeklyCommits:   []int{0, 3, 12},
 			Description:     "Test repository with, comma",
 			PrimaryLanguage: "Go",
 			IsArchived:      false,
@@ -194,6 +195,7 @@ func TestCSVFormatter(t *testing.T) {
 		"Yes",
 		"Yes",
 		"No",
+		",0 3 12,",
 		`"Test repository with, comma"`,
 	}
 
@@ -201,3 +203,164 @@ func TestCSVFormatter(t *testing.T) {
 		require.Contains(t, dataRow, d, "CSV data row missing expected value")
 	}
 }
+
+func TestFormatErrors(t *testing.T) {
+	errs := []*ErrorRecord{
+		{Repository: "test/missing", Code: "NOT_FOUND", Message: "repository not found, or private"},
+	}
+
+	for _, format := range []string{FormatTable, FormatJSON, FormatJSONCompact, FormatCSV} {
+		t.Run(format, func(t *testing.T) {
+			formatter, err := New(format)
+			require.NoError(t, err)
+
+			buf := &bytes.Buffer{}
+			require.NoError(t, formatter.FormatErrors(buf, errs))
+
+			output := buf.String()
+			require.Contains(t, output, "test/missing")
+			require.Contains(t, output, "NOT_FOUND")
+			require.Contains(t, output, "repository not found, or private")
+		})
+	}
+}
+
+func TestFormatLicenseViolation(t *testing.T) {
+	repos := []*metrics.Repository{
+		{
+			Owner:            "test",
+			Name:             "repo",
+			HasLicense:       true,
+			LicenseSPDX:      "AGPL-3.0",
+			LicenseClass:     "strong-copyleft",
+			LicenseViolation: "license AGPL-3.0 (strong-copyleft) is denied",
+		},
+	}
+
+	for _, format := range []string{FormatTable, FormatJSON, FormatJSONCompact, FormatCSV} {
+		t.Run(format, func(t *testing.T) {
+			formatter, err := New(format)
+			require.NoError(t, err)
+
+			buf := &bytes.Buffer{}
+			require.NoError(t, formatter.Format(buf, repos))
+
+			output := buf.String()
+			require.Contains(t, output, "AGPL-3.0")
+			require.Contains(t, output, "strong-copyleft")
+			require.Contains(t, output, "(strong-copyleft) is denied")
+		})
+	}
+}
+
+func TestFormatHours(t *testing.T) {
+	require.Equal(t, "N/A", formatHours(0))
+	require.Equal(t, "5.5h", formatHours(5.5))
+	require.Equal(t, "4.0d", formatHours(96))
+}
+
+func TestSparkline(t *testing.T) {
+	require.Equal(t, "N/A", sparkline(nil, 13))
+	require.Equal(t, "▁▁▁", sparkline([]int{0, 0, 0}, 13))
+	require.Equal(t, "▁▄█", sparkline([]int{0, 1, 2}, 13))
+	require.Equal(t, "▁█", sparkline([]int{0, 0, 1, 1}, 2))
+}
+
+func TestBranchProtection(t *testing.T) {
+	require.Equal(t, "No", branchProtection(&Record{BranchProtected: "No", RequiredReviews: 2}))
+	require.Equal(t, "Unknown", branchProtection(&Record{BranchProtected: "Unknown"}))
+	require.Equal(t, "Yes", branchProtection(&Record{BranchProtected: "Yes"}))
+	require.Equal(t, "Yes (2 reviews, checks)", branchProtection(&Record{BranchProtected: "Yes", RequiredReviews: 2, RequiredStatusChecks: "Yes"}))
+}
+
+func TestFormatLanguages(t *testing.T) {
+	languages := []metrics.LanguageSize{
+		{Name: "Go", Bytes: 3 * 1024 * 1024},
+		{Name: "Shell", Bytes: 20480},
+		{Name: "Makefile", Bytes: 512},
+		{Name: "Dockerfile", Bytes: 300},
+	}
+
+	require.Equal(t, "", formatLanguages(nil, 3))
+	require.Equal(t, "Go (3.0 MB), Shell (20.0 KB), Makefile (512 B), +1 more", formatLanguages(languages, 3))
+	require.Equal(t, "N/A", formatKB(0))
+	require.Equal(t, "1.5 GB", formatKB(1536*1024))
+}
+
+func TestFormatCommunityFiles(t *testing.T) {
+	files := map[string]string{
+		metrics.CommunitySecurity: ".github/SECURITY.md",
+		metrics.CommunityReadme:   "README.md",
+		metrics.CommunityFunding:  ".github/FUNDING.yml",
+	}
+
+	require.Equal(t, "", formatCommunityFiles(nil))
+	require.Equal(t, "readme=README.md, security=.github/SECURITY.md, funding=.github/FUNDING.yml", formatCommunityFiles(files))
+}
+
+func TestFormatMetadata(t *testing.T) {
+	repos := []*metrics.Repository{
+		{
+			Owner:         "test",
+			Name:          "repo",
+			Languages:     []metrics.LanguageSize{{Name: "Go", Bytes: 2048}},
+			Topics:        []string{"cli", "github"},
+			HomepageURL:   "https://example.com",
+			IsFork:        true,
+			Parent:        "upstream/repo",
+			CreatedAt:     time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+			DiskUsageKB:   2048,
+			DefaultBranch: "main",
+		},
+	}
+
+	formatter := &JSONFormatter{}
+	buf := &bytes.Buffer{}
+	require.NoError(t, formatter.Format(buf, repos))
+
+	var result []Record
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	require.Len(t, result, 1)
+	require.Equal(t, repos[0].Languages, result[0].Languages)
+	require.Equal(t, []string{"cli", "github"}, result[0].Topics)
+	require.Equal(t, "Yes", result[0].Fork)
+	require.Equal(t, "upstream/repo", result[0].Parent)
+	require.Equal(t, "No", result[0].Template)
+	require.Equal(t, "2020-01-02", result[0].CreatedAt)
+	require.Positive(t, result[0].AgeDays)
+	require.Equal(t, "main", result[0].DefaultBranch)
+
+	buf.Reset()
+	require.NoError(t, (&CSVFormatter{}).Format(buf, repos))
+	output := buf.String()
+	require.Contains(t, output, "Go (2.0 KB)")
+	require.Contains(t, output, "Yes (upstream/repo)")
+	require.Contains(t, output, "2.0 MB")
+}
+
+func TestFormatSBOMComponents(t *testing.T) {
+	repos := []*metrics.Repository{
+		{
+			Owner: "go-chi",
+			Name:  "chi",
+			SBOMComponents: []metrics.SBOMComponent{
+				{Name: "github.com/go-chi/chi", Version: "v1.5.5", Ref: "SPDXRef-Package-chi-v1"},
+				{Name: "github.com/go-chi/chi/v5", Version: "v5.2.2", Ref: "SPDXRef-Package-chi-v5"},
+			},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, (&JSONFormatter{}).Format(buf, repos))
+	var result []Record
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	require.Equal(t, repos[0].SBOMComponents, result[0].SBOMComponents)
+
+	buf.Reset()
+	require.NoError(t, (&CSVFormatter{}).Format(buf, repos))
+	require.Contains(t, buf.String(), "github.com/go-chi/chi@v1.5.5, github.com/go-chi/chi/v5@v5.2.2")
+
+	buf.Reset()
+	require.NoError(t, (&JSONFormatter{}).Format(buf, []*metrics.Repository{{Owner: "a", Name: "b"}}))
+	require.NotContains(t, buf.String(), "sbom_components")
+}
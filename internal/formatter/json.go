@@ -21,9 +21,17 @@ func (f *JSONFormatter) Format(writer io.Writer, metricsData []*metrics.Reposito
 		records = append(records, MetricsToRecord(m))
 	}
 
+	return f.encode(writer, records)
+}
+
+func (f *JSONFormatter) FormatErrors(writer io.Writer, errors []*ErrorRecord) error {
+	return f.encode(writer, errors)
+}
+
+func (f *JSONFormatter) encode(writer io.Writer, v interface{}) error {
 	encoder := json.NewEncoder(writer)
 	if f.indent {
 		encoder.SetIndent("", "  ")
 	}
-	return encoder.Encode(records)
+	return encoder.Encode(v)
 }
@@ -0,0 +1,35 @@
+package formatter
+
+import "strings"
+
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline draws values as a row of block characters, summing consecutive
+// values so that the line is at most width characters wide.
+func sparkline(values []int, width int) string {
+	if len(values) == 0 {
+		return "N/A"
+	}
+
+	binSize := (len(values) + width - 1) / width
+	var bins []int
+	peak := 0
+	for start := 0; start < len(values); start += binSize {
+		sum := 0
+		for _, v := range values[start:min(start+binSize, len(values))] {
+			sum += v
+		}
+		bins = append(bins, sum)
+		peak = max(peak, sum)
+	}
+
+	var b strings.Builder
+	for _, bin := range bins {
+		tick := 0
+		if peak > 0 {
+			tick = bin * (len(sparkTicks) - 1) / peak
+		}
+		b.WriteRune(sparkTicks[tick])
+	}
+	return b.String()
+}
@@ -15,8 +15,32 @@ func NewTableFormatter() *TableFormatter {
 }
 
 func (f *TableFormatter) Format(writer io.Writer, metricsData []*metrics.Repository) error {
+	table := newTable(writer, GetRecordHeaders())
+
+	for _, m := range metricsData {
+		row := MetricsToRecord(m).Strings()
+		table.Append(row)
+	}
+
+	table.Render()
+	return nil
+}
+
+func (f *TableFormatter) FormatErrors(writer io.Writer, errors []*ErrorRecord) error {
+	table := newTable(writer, GetErrorRecordHeaders())
+	table.SetAutoWrapText(false)
+
+	for _, e := range errors {
+		table.Append(e.Strings())
+	}
+
+	table.Render()
+	return nil
+}
+
+func newTable(writer io.Writer, headers []string) *tablewriter.Table {
 	table := tablewriter.NewWriter(writer)
-	table.SetHeader(GetRecordHeaders())
+	table.SetHeader(headers)
 
 	table.SetBorder(false)
 	table.SetCenterSeparator("")
@@ -25,11 +49,5 @@ func (f *TableFormatter) Format(writer io.Writer, metricsData []*metrics.Reposit
 	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
 	table.SetAlignment(tablewriter.ALIGN_LEFT)
 
-	for _, m := range metricsData {
-		row := MetricsToRecord(m).Strings()
-		table.Append(row)
-	}
-
-	table.Render()
-	return nil
+	return table
 }
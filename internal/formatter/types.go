@@ -2,6 +2,8 @@ package formatter
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/kdimtriCP/gh-inspector/internal/metrics"
@@ -12,6 +14,11 @@ const (
 	FormatJSON        = "json"
 	FormatJSONCompact = "json-compact"
 	FormatCSV         = "csv"
+
+	// The yearly commit series is drawn in four-week steps.
+	sparklineWidth = 13
+
+	maxTableLanguages = 3
 )
 
 // Record represents a scored repository
@@ -33,16 +40,94 @@ type Record struct {
 	OpenPRs int `json:"open_prs" example:"300"`
 	// Last commit relative time
 	LastCommit string `json:"last_commit" example:"1 days ago"`
+	// Default-branch commits per week over the past year, oldest first
+	WeeklyCommits []int `json:"weekly_commits" example:"12,9,15,20"`
+	// Distinct commit authors in the history window
+	Contributors int `json:"contributors" example:"120"`
+	// Share of commits in the history window made by the most active author (0-1)
+	TopContributorShare float64 `json:"top_contributor_share" example:"0.12"`
+	// Fewest authors that made at least half of the commits in the history window
+	BusFactor int `json:"bus_factor" example:"9"`
+	// Issues opened in the history window
+	RecentIssues int `json:"recent_issues" example:"85"`
+	// Median hours from opening to the first maintainer comment, for recent issues
+	MedianIssueResponseHours float64 `json:"median_issue_response_hours" example:"5.5"`
+	// Median hours from opening to closing, for recent issues
+	MedianIssueCloseHours float64 `json:"median_issue_close_hours" example:"96"`
+	// Share of recent issues still open without a maintainer comment (0-1)
+	UnansweredIssueShare float64 `json:"unanswered_issue_share" example:"0.1"`
+	// Pull requests merged in the history window
+	MergedPRs int `json:"merged_prs" example:"140"`
+	// Pull requests closed without merging in the history window
+	ClosedUnmergedPRs int `json:"closed_unmerged_prs" example:"25"`
+	// Share of closed pull requests in the history window that were merged (0-1)
+	MergeRatio float64 `json:"merge_ratio" example:"0.85"`
+	// Median hours from opening to merge, for recently merged pull requests
+	MedianPRMergeHours float64 `json:"median_pr_merge_hours" example:"30"`
+	// Share of recently merged pull requests with an approving review (0-1)
+	ApprovedMergeShare float64 `json:"approved_merge_share" example:"0.9"`
+	// Open pull requests without updates for 30 days
+	StalePRs int `json:"stale_prs" example:"12"`
 	// Number of releases
 	Releases int `json:"releases" example:"350"`
 	// Last release relative time
 	LastRelease string `json:"last_release" example:"7 days ago"`
+	// Median days between consecutive releases, over the last 10 releases
+	MedianReleaseIntervalDays float64 `json:"median_release_interval_days" example:"21"`
+	// Share of the last 10 releases tagged with a semantic version (0-1)
+	SemverReleaseShare float64 `json:"semver_release_share" example:"1"`
+	// Share of the last 10 releases marked as pre-releases (0-1)
+	PrereleaseShare float64 `json:"prerelease_share" example:"0.3"`
+	// Share of the last 10 releases with release notes (0-1)
+	ReleaseNotesShare float64 `json:"release_notes_share" example:"1"`
+	// Major version bumps among the last 10 releases within the past year
+	RecentMajorBumps int `json:"recent_major_bumps" example:"0"`
 	// Primary programming language
 	Language string `json:"language" example:"Go"`
+	// Languages by size in bytes, largest first, at most 20
+	Languages []metrics.LanguageSize `json:"languages"`
+	// Repository topics
+	Topics []string `json:"topics" example:"kubernetes,containers"`
+	// Homepage URL
+	Homepage string `json:"homepage" example:"https://kubernetes.io"`
+	// Fork status
+	Fork string `json:"fork" example:"No" enums:"Yes,No"`
+	// Repository this one was forked from
+	Parent string `json:"parent,omitempty" example:"kubernetes/kubernetes"`
+	// Template repository status
+	Template string `json:"template" example:"No" enums:"Yes,No"`
+	// Creation date
+	CreatedAt string `json:"created_at" example:"2014-06-06"`
+	// Days since the repository was created, -1 when unknown
+	AgeDays int `json:"age_days" example:"4100"`
+	// Repository size on disk in kilobytes
+	DiskUsageKB int `json:"disk_usage_kb" example:"1200000"`
+	// Default branch name
+	DefaultBranch string `json:"default_branch" example:"master"`
 	// CI/CD presence
 	CICD string `json:"ci_cd" example:"Yes" enums:"Yes,No"`
+	// CI services running builds or tests
+	CIProviders []string `json:"ci_providers" example:"GitHub Actions"`
+	// GitHub Actions workflows that build or test on pushes and pull requests
+	TestWorkflows int `json:"test_workflows" example:"3"`
+	// GitHub Actions workflows that only run for releases or tags
+	ReleaseWorkflows int `json:"release_workflows" example:"1"`
+	// GitHub Actions workflows that triage issues and pull requests or run on a schedule
+	BotWorkflows int `json:"bot_workflows" example:"2"`
+	// Dependabot version updates configuration presence
+	Dependabot string `json:"dependabot" example:"Yes" enums:"Yes,No"`
+	// Renovate configuration presence
+	Renovate string `json:"renovate" example:"No" enums:"Yes,No"`
+	// CodeQL code scanning presence
+	CodeQL string `json:"codeql" example:"Yes" enums:"Yes,No"`
 	// License presence
 	License string `json:"license" example:"Yes" enums:"Yes,No"`
+	// SPDX identifier of the license, NOASSERTION when GitHub could not identify it
+	LicenseID string `json:"license_id" example:"Apache-2.0"`
+	// License family
+	LicenseClass string `json:"license_class" example:"permissive" enums:"permissive,weak-copyleft,strong-copyleft,unknown,none"`
+	// Why the license violates the configured license policy
+	LicenseViolation string `json:"license_violation,omitempty" example:"license AGPL-3.0 (strong-copyleft) is denied"`
 	// Contributing guide presence
 	Contributing string `json:"contributing" example:"Yes" enums:"Yes,No"`
 	// README presence
@@ -51,10 +136,45 @@ type Record struct {
 	CodeOfConduct string `json:"code_of_conduct" example:"Yes" enums:"Yes,No"`
 	// Security policy presence
 	Security string `json:"security" example:"Yes" enums:"Yes,No"`
+	// Branch protection or rulesets on the default branch
+	BranchProtected string `json:"branch_protected" example:"Yes" enums:"Yes,No,Unknown"`
+	// Approving reviews the default branch rulesets require before merging
+	RequiredReviews int `json:"required_reviews" example:"1"`
+	// Whether status checks must pass before merging into the default branch
+	RequiredStatusChecks string `json:"required_status_checks" example:"Yes" enums:"Yes,No"`
+	// Dependabot vulnerability alerts, only visible to repository admins
+	VulnerabilityAlerts string `json:"vulnerability_alerts" example:"Yes" enums:"Yes,No,Unknown"`
+	// Published security advisories, counting at most 100
+	SecurityAdvisories int `json:"security_advisories" example:"12"`
+	// Path of the file satisfying each community health check found in the root, .github or docs
+	CommunityFiles map[string]string `json:"community_files"`
 	// Repository description
 	Description string `json:"description" example:"Production-Grade Container Scheduling and Management"`
 	// Archive status
 	Archived string `json:"archived" example:"No" enums:"Yes,No"`
+	// Name the repository was requested under before it was renamed or transferred
+	MovedFrom string `json:"moved_from,omitempty" example:"kubernetes/kubernetes-old"`
+	// SBOM entries that resolved to the repository, when scoring an SBOM
+	SBOMComponents []metrics.SBOMComponent `json:"sbom_components,omitempty"`
+}
+
+// ErrorRecord represents a repository that could not be scored
+// @Description Repository scoring failure
+type ErrorRecord struct {
+	// Repository as given in the request
+	Repository string `json:"repository" example:"kubernetes/no-such-repo"`
+	// Error code
+	Code string `json:"code" example:"NOT_FOUND" enums:"NOT_FOUND,ACCESS_DENIED,RATE_LIMITED,INVALID_NAME,UPSTREAM_FAILURE,TIMEOUT,CANCELED"`
+	// Error message
+	Message string `json:"message" example:"Could not resolve to a Repository with the name 'kubernetes/no-such-repo'."`
+}
+
+func (e *ErrorRecord) Strings() []string {
+	return []string{e.Repository, e.Code, e.Message}
+}
+
+func GetErrorRecordHeaders() []string {
+	return []string{"Repository", "Code", "Message"}
 }
 
 func MetricsToRecord(m *metrics.Repository) *Record {
@@ -79,6 +199,11 @@ func MetricsToRecord(m *metrics.Repository) *Record {
 		lang = "N/A"
 	}
 
+	createdAt := ""
+	if !m.CreatedAt.IsZero() {
+		createdAt = m.CreatedAt.Format(time.DateOnly)
+	}
+
 	archived := "No"
 	if m.IsArchived {
 		archived = "Yes"
@@ -111,25 +236,72 @@ func MetricsToRecord(m *metrics.Repository) *Record {
 	}
 
 	return &Record{
-		Repository:    fmt.Sprintf("%s/%s", m.Owner, m.Name),
-		Score:         m.Score,
-		Stars:         m.Stars,
-		Forks:         m.Forks,
-		Watchers:      m.Watchers,
-		OpenIssues:    m.OpenIssues,
-		OpenPRs:       m.OpenPRs,
-		LastCommit:    lastCommit,
-		Releases:      m.ReleaseCount,
-		LastRelease:   lastRelease,
-		Language:      lang,
-		CICD:          cicd,
-		License:       license,
-		Contributing:  contributing,
-		Readme:        readme,
-		CodeOfConduct: codeOfConduct,
-		Security:      security,
-		Description:   m.Description,
-		Archived:      archived,
+		Repository:                m.FullName(),
+		Score:                     m.Score,
+		Stars:                     m.Stars,
+		Forks:                     m.Forks,
+		Watchers:                  m.Watchers,
+		OpenIssues:                m.OpenIssues,
+		OpenPRs:                   m.OpenPRs,
+		LastCommit:                lastCommit,
+		WeeklyCommits:             m.WeeklyCommits,
+		Contributors:              m.ContributorCount,
+		TopContributorShare:       m.TopContributorShare,
+		BusFactor:                 m.BusFactor,
+		RecentIssues:              m.RecentIssueCount,
+		MedianIssueResponseHours:  m.MedianIssueResponse.Hours(),
+		MedianIssueCloseHours:     m.MedianIssueCloseTime.Hours(),
+		UnansweredIssueShare:      m.UnansweredIssueShare,
+		MergedPRs:                 m.RecentMergedPRs,
+		ClosedUnmergedPRs:         m.RecentClosedPRs,
+		MergeRatio:                m.MergeRatio(),
+		MedianPRMergeHours:        m.MedianPRMergeTime.Hours(),
+		ApprovedMergeShare:        m.ApprovedMergeShare,
+		StalePRs:                  m.StalePRCount,
+		Releases:                  m.ReleaseCount,
+		LastRelease:               lastRelease,
+		MedianReleaseIntervalDays: m.MedianReleaseInterval.Hours() / 24,
+		SemverReleaseShare:        m.SemverReleaseShare,
+		PrereleaseShare:           m.PrereleaseShare,
+		ReleaseNotesShare:         m.ReleaseNotesShare,
+		RecentMajorBumps:          m.RecentMajorBumps,
+		Language:                  lang,
+		Languages:                 m.Languages,
+		Topics:                    m.Topics,
+		Homepage:                  m.HomepageURL,
+		Fork:                      yesNo(m.IsFork),
+		Parent:                    m.Parent,
+		Template:                  yesNo(m.IsTemplate),
+		CreatedAt:                 createdAt,
+		AgeDays:                   m.AgeDays(),
+		DiskUsageKB:               m.DiskUsageKB,
+		DefaultBranch:             m.DefaultBranch,
+		CICD:                      cicd,
+		CIProviders:               m.CIProviders,
+		TestWorkflows:             m.TestWorkflows,
+		ReleaseWorkflows:          m.ReleaseWorkflows,
+		BotWorkflows:              m.BotWorkflows,
+		Dependabot:                yesNo(m.HasDependabot),
+		Renovate:                  yesNo(m.HasRenovate),
+		CodeQL:                    yesNo(m.HasCodeQL),
+		License:                   license,
+		LicenseID:                 m.LicenseSPDX,
+		LicenseClass:              m.LicenseClass,
+		LicenseViolation:          m.LicenseViolation,
+		Contributing:              contributing,
+		Readme:                    readme,
+		CodeOfConduct:             codeOfConduct,
+		Security:                  security,
+		BranchProtected:           yesNoUnknown(m.BranchProtected),
+		RequiredReviews:           m.RequiredReviews,
+		RequiredStatusChecks:      yesNo(m.RequiresStatusChecks),
+		VulnerabilityAlerts:       yesNoUnknown(m.VulnerabilityAlerts),
+		SecurityAdvisories:        m.SecurityAdvisories,
+		CommunityFiles:            m.CommunityFiles,
+		Description:               m.Description,
+		Archived:                  archived,
+		MovedFrom:                 m.MovedFrom,
+		SBOMComponents:            m.SBOMComponents,
 	}
 }
 
@@ -153,7 +325,19 @@ func (r *Record) String() string {
 	)
 }
 
+// Strings renders the record as a table row, drawing commit activity as a
+// sparkline.
 func (r *Record) Strings() []string {
+	return r.row(sparkline(r.WeeklyCommits, sparklineWidth))
+}
+
+// CSVStrings renders the record as a CSV row, listing the weekly commit
+// counts instead of a sparkline.
+func (r *Record) CSVStrings() []string {
+	return r.row(formatCounts(r.WeeklyCommits))
+}
+
+func (r *Record) row(commitActivity string) []string {
 	return []string{
 		r.Repository,
 		fmt.Sprintf("%.1f", r.Score),
@@ -163,15 +347,197 @@ func (r *Record) Strings() []string {
 		fmt.Sprintf("%d", r.OpenIssues),
 		fmt.Sprintf("%d", r.OpenPRs),
 		r.LastCommit,
+		commitActivity,
+		fmt.Sprintf("%d", r.Contributors),
+		formatShare(r.TopContributorShare, r.Contributors),
+		fmt.Sprintf("%d", r.BusFactor),
+		formatHours(r.MedianIssueResponseHours),
+		formatHours(r.MedianIssueCloseHours),
+		formatShare(r.UnansweredIssueShare, r.RecentIssues),
+		formatHours(r.MedianPRMergeHours),
+		formatShare(r.MergeRatio, r.MergedPRs+r.ClosedUnmergedPRs),
+		formatShare(r.ApprovedMergeShare, r.MergedPRs),
+		fmt.Sprintf("%d", r.StalePRs),
 		fmt.Sprintf("%d", r.Releases),
 		r.LastRelease,
 		r.Language,
+		formatLanguages(r.Languages, maxTableLanguages),
+		strings.Join(r.Topics, ", "),
+		r.Homepage,
+		fork(r),
+		r.Template,
+		valueOrNA(r.CreatedAt),
+		formatKB(r.DiskUsageKB),
+		valueOrNA(r.DefaultBranch),
 		r.CICD,
+		dependencyUpdates(r.Dependabot, r.Renovate),
+		r.CodeQL,
+		branchProtection(r),
 		r.License,
+		valueOrNA(r.LicenseID),
+		valueOrNA(r.LicenseClass),
+		r.LicenseViolation,
 		r.Contributing,
+		formatCommunityFiles(r.CommunityFiles),
 		r.Description,
 		r.Archived,
+		r.MovedFrom,
+		formatComponents(r.SBOMComponents),
+	}
+}
+
+// formatCounts lists counts separated by spaces, or N/A when there are none.
+func formatCounts(counts []int) string {
+	if len(counts) == 0 {
+		return "N/A"
+	}
+	values := make([]string, len(counts))
+	for i, count := range counts {
+		values[i] = strconv.Itoa(count)
+	}
+	return strings.Join(values, " ")
+}
+
+// formatHours renders a duration given in hours, or N/A when there was nothing
+// to measure.
+func formatHours(hours float64) string {
+	switch {
+	case hours <= 0:
+		return "N/A"
+	case hours < 48:
+		return fmt.Sprintf("%.1fh", hours)
+	default:
+		return fmt.Sprintf("%.1fd", hours/24)
+	}
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "Yes"
+	}
+	return "No"
+}
+
+// yesNoUnknown is yesNo for settings the token may not be allowed to read.
+func yesNoUnknown(value *bool) string {
+	if value == nil {
+		return "Unknown"
+	}
+	return yesNo(*value)
+}
+
+func dependencyUpdates(dependabot, renovate string) string {
+	var tools []string
+	if dependabot == "Yes" {
+		tools = append(tools, "Dependabot")
+	}
+	if renovate == "Yes" {
+		tools = append(tools, "Renovate")
+	}
+	if len(tools) == 0 {
+		return "No"
+	}
+	return strings.Join(tools, ", ")
+}
+
+// branchProtection summarizes what the default branch requires before merging.
+func branchProtection(r *Record) string {
+	if r.BranchProtected != "Yes" {
+		return r.BranchProtected
+	}
+
+	var required []string
+	if r.RequiredReviews > 0 {
+		required = append(required, fmt.Sprintf("%d reviews", r.RequiredReviews))
+	}
+	if r.RequiredStatusChecks == "Yes" {
+		required = append(required, "checks")
+	}
+	if len(required) == 0 {
+		return "Yes"
+	}
+	return "Yes (" + strings.Join(required, ", ") + ")"
+}
+
+func fork(r *Record) string {
+	if r.Fork == "Yes" && r.Parent != "" {
+		return "Yes (" + r.Parent + ")"
+	}
+	return r.Fork
+}
+
+// formatLanguages lists the largest languages with their sizes, noting how
+// many more were left out.
+func formatLanguages(languages []metrics.LanguageSize, limit int) string {
+	var parts []string
+	for i, language := range languages {
+		if i == limit {
+			parts = append(parts, fmt.Sprintf("+%d more", len(languages)-limit))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", language.Name, formatBytes(language.Bytes)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatCommunityFiles lists the file found for each community health check,
+// in check order, such as readme=README.md, security=.github/SECURITY.md.
+func formatCommunityFiles(files map[string]string) string {
+	var parts []string
+	for _, check := range metrics.CommunityChecks {
+		if path, ok := files[check]; ok {
+			parts = append(parts, check+"="+path)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatComponents(components []metrics.SBOMComponent) string {
+	names := make([]string, 0, len(components))
+	for _, c := range components {
+		if c.Version == "" {
+			names = append(names, c.Name)
+		} else {
+			names = append(names, c.Name+"@"+c.Version)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func formatKB(kb int) string {
+	if kb <= 0 {
+		return "N/A"
+	}
+	return formatBytes(kb * 1024)
+}
+
+func formatBytes(bytes int) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value := float64(bytes) / unit
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		if value < unit {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+	return fmt.Sprintf("%.1f TB", value)
+}
+
+func valueOrNA(value string) string {
+	if value == "" {
+		return "N/A"
+	}
+	return value
+}
+
+func formatShare(share float64, sample int) string {
+	if sample == 0 {
+		return "N/A"
 	}
+	return fmt.Sprintf("%.0f%%", share*100)
 }
 
 func GetRecordHeaders() []string {
@@ -184,13 +550,41 @@ func GetRecordHeaders() []string {
 		"Open Issues",
 		"Open PRs",
 		"Last Commit",
+		"Commit Activity",
+		"Contributors",
+		"Top Contributor",
+		"Bus Factor",
+		"Issue Response",
+		"Issue Close",
+		"Unanswered Issues",
+		"PR Merge Time",
+		"PRs Merged",
+		"PRs Approved",
+		"Stale PRs",
 		"Releases",
 		"Last Release",
 		"Language",
+		"Languages",
+		"Topics",
+		"Homepage",
+		"Fork",
+		"Template",
+		"Created",
+		"Disk Usage",
+		"Default Branch",
 		"CI/CD",
+		"Dependency Updates",
+		"CodeQL",
+		"Branch Protection",
 		"License",
+		"License ID",
+		"License Class",
+		"License Violation",
 		"Contributing",
+		"Community Files",
 		"Description",
 		"Archived",
+		"Moved From",
+		"SBOM Components",
 	}
 }
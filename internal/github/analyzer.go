@@ -3,44 +3,224 @@ package github
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/kdimtriCP/gh-inspector/internal/cache"
+	"github.com/kdimtriCP/gh-inspector/internal/license"
 	"github.com/kdimtriCP/gh-inspector/internal/metrics"
 	"github.com/kdimtriCP/gh-inspector/internal/scoring"
 )
 
 type RepoAnalyzer struct {
-	client *Client
-	scorer *scoring.Scorer
+	client        *Client
+	clients       map[string]*Client
+	scorer        *scoring.Scorer
+	licensePolicy *license.Policy
 }
 
 func NewRepoAnalyzer(token string, scoringConfig *scoring.Config) *RepoAnalyzer {
+	client := NewClient(token)
 	return &RepoAnalyzer{
-		client: NewClient(token),
-		scorer: scoring.NewScorer(scoringConfig),
+		client:  client,
+		clients: map[string]*Client{DefaultHost: client},
+		scorer:  scoring.NewScorer(scoringConfig),
 	}
 }
 
+// AddHost registers the GitHub instance described by config. Repositories
+// prefixed with its host name are analyzed through it. Settings already applied
+// to the analyzer carry over to the new client.
+func (ra *RepoAnalyzer) AddHost(config HostConfig) error {
+	client, err := NewHostClient(config)
+	if err != nil {
+		return err
+	}
+
+	client.cache = ra.client.cache
+	client.cacheTTL = ra.client.cacheTTL
+	client.batchSize = ra.client.batchSize
+	client.SetConcurrency(cap(ra.client.restSlots))
+	client.historyWindow = ra.client.historyWindow
+	client.retryConfig = ra.client.retryConfig
+	client.SetMetricsRecorder(ra.client.metricsRecorder)
+	client.SetRateLimitReserve(ra.client.rateLimiter.reserve)
+
+	if client.host == DefaultHost {
+		ra.client = client
+	}
+	ra.clients[client.host] = client
+	return nil
+}
+
 func (ra *RepoAnalyzer) SetCache(c cache.Cache) {
-	ra.client.SetCache(c)
+	for _, client := range ra.clients {
+		client.SetCache(c)
+	}
 }
 
 func (ra *RepoAnalyzer) SetCacheTTL(ttl time.Duration) {
-	ra.client.SetCacheTTL(ttl)
+	for _, client := range ra.clients {
+		client.SetCacheTTL(ttl)
+	}
+}
+
+func (ra *RepoAnalyzer) SetBatchSize(size int) {
+	for _, client := range ra.clients {
+		client.SetBatchSize(size)
+	}
+}
+
+func (ra *RepoAnalyzer) SetConcurrency(concurrency int) {
+	for _, client := range ra.clients {
+		client.SetConcurrency(concurrency)
+	}
+}
+
+func (ra *RepoAnalyzer) SetHistoryWindow(window time.Duration) {
+	for _, client := range ra.clients {
+		client.SetHistoryWindow(window)
+	}
+}
+
+func (ra *RepoAnalyzer) SetRateLimitReserve(reserve int) {
+	for _, client := range ra.clients {
+		client.SetRateLimitReserve(reserve)
+	}
+}
+
+func (ra *RepoAnalyzer) SetRetryConfig(config RetryConfig) {
+	for _, client := range ra.clients {
+		client.SetRetryConfig(config)
+	}
 }
 
 func (ra *RepoAnalyzer) SetMetricsRecorder(recorder metrics.Recorder) {
-	ra.client.SetMetricsRecorder(recorder)
+	for _, client := range ra.clients {
+		client.SetMetricsRecorder(recorder)
+	}
+}
+
+// SetLicensePolicy flags repositories whose license violates policy and
+// limits their score as the policy says.
+func (ra *RepoAnalyzer) SetLicensePolicy(policy *license.Policy) {
+	ra.licensePolicy = policy
+}
+
+func (ra *RepoAnalyzer) clientFor(url string) (*Client, string, error) {
+	ref, err := ParseReference(url)
+	if err != nil {
+		return nil, "", err
+	}
+	client, ok := ra.clients[ref.Host]
+	if !ok {
+		return nil, "", &Error{
+			Code:       CodeInvalidName,
+			Repository: url,
+			Err:        fmt.Errorf("no configuration for GitHub host %s", ref.Host),
+		}
+	}
+	return client, ref.FullName(), nil
+}
+
+// ListRepositories lists the repositories of an organization or user that
+// match filter. A login prefixed with a configured host, such as
+// github.example.com/platform, is looked up on that instance.
+func (ra *RepoAnalyzer) ListRepositories(ctx context.Context, kind OwnerKind, login string, filter DiscoveryFilter) ([]string, error) {
+	client := ra.client
+	if host, name, ok := strings.Cut(login, "/"); ok {
+		client, ok = ra.clients[strings.ToLower(host)]
+		if !ok {
+			return nil, &Error{
+				Code:       CodeInvalidName,
+				Repository: login,
+				Err:        fmt.Errorf("no configuration for GitHub host %s", host),
+			}
+		}
+		login = name
+	}
+	return client.ListRepositories(ctx, kind, login, filter)
+}
+
+// SearchRepositories runs a repository search on github.com.
+func (ra *RepoAnalyzer) SearchRepositories(ctx context.Context, query string, limit int) ([]string, error) {
+	return ra.client.SearchRepositories(ctx, query, limit)
 }
 
 func (ra *RepoAnalyzer) Analyze(ctx context.Context, url string) (*metrics.Repository, error) {
-	repo, err := ra.client.CollectBasicMetrics(ctx, url)
+	client, repoFullName, err := ra.clientFor(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to collect metrics for %s: %w", url, err)
 	}
 
-	repo.Score = ra.scorer.Score(repo)
+	repo, err := client.CollectBasicMetrics(ctx, repoFullName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect metrics for %s: %w", url, err)
+	}
+
+	ra.score(repo)
 
 	return repo, nil
 }
+
+func (ra *RepoAnalyzer) BatchSize() int {
+	return ra.client.BatchSize()
+}
+
+func (ra *RepoAnalyzer) AnalyzeBatch(ctx context.Context, urls []string) ([]*metrics.Repository, []error) {
+	repos := make([]*metrics.Repository, len(urls))
+	errs := make([]error, len(urls))
+
+	// Group by host so each instance still gets a single batched query.
+	type hostBatch struct {
+		client  *Client
+		names   []string
+		indices []int
+	}
+	var order []string
+	batches := make(map[string]*hostBatch)
+	for i, url := range urls {
+		client, repoFullName, err := ra.clientFor(url)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		batch, ok := batches[client.host]
+		if !ok {
+			batch = &hostBatch{client: client}
+			batches[client.host] = batch
+			order = append(order, client.host)
+		}
+		batch.names = append(batch.names, repoFullName)
+		batch.indices = append(batch.indices, i)
+	}
+
+	for _, host := range order {
+		batch := batches[host]
+		results, batchErrs := batch.client.CollectBatchMetrics(ctx, batch.names)
+		for j, i := range batch.indices {
+			repos[i], errs[i] = results[j], batchErrs[j]
+		}
+	}
+
+	for i, repo := range repos {
+		if errs[i] != nil {
+			errs[i] = fmt.Errorf("failed to collect metrics for %s: %w", urls[i], errs[i])
+			continue
+		}
+		ra.score(repo)
+	}
+
+	return repos, errs
+}
+
+func (ra *RepoAnalyzer) score(repo *metrics.Repository) {
+	repo.Score = ra.scorer.Score(repo)
+	if ra.licensePolicy == nil {
+		return
+	}
+	if violation := ra.licensePolicy.Check(repo.LicenseSPDX); violation != "" {
+		repo.LicenseViolation = violation
+		repo.Score = ra.licensePolicy.Limit(repo.Score)
+	}
+}
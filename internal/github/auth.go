@@ -0,0 +1,171 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	// GitHub rejects app JWTs valid for longer than ten minutes.
+	appJWTLifetime = 9 * time.Minute
+	// Backdating protects against clock drift between us and GitHub.
+	appJWTBackdate = 60 * time.Second
+	// Installation tokens last an hour; refresh them well before that.
+	installationTokenEarlyExpiry = 5 * time.Minute
+	// Bounds the installation token exchange, which has no caller context.
+	installationTokenTimeout = 30 * time.Second
+)
+
+// AppConfig holds GitHub App installation credentials.
+type AppConfig struct {
+	AppID          int64  `mapstructure:"app_id"`
+	InstallationID int64  `mapstructure:"installation_id"`
+	PrivateKeyFile string `mapstructure:"private_key_file"`
+}
+
+func (ac *AppConfig) Enabled() bool {
+	return ac != nil && ac.AppID != 0
+}
+
+// NewTokenSource returns the token source for a host: installation tokens when
+// a GitHub App is configured, the static token otherwise.
+func NewTokenSource(config HostConfig, transport http.RoundTripper) (oauth2.TokenSource, error) {
+	if !config.App.Enabled() {
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: config.Token}), nil
+	}
+
+	src, err := newAppTokenSource(*config.App, config.restURL(), &http.Client{Transport: transport})
+	if err != nil {
+		return nil, err
+	}
+	return oauth2.ReuseTokenSourceWithExpiry(nil, src, installationTokenEarlyExpiry), nil
+}
+
+type appTokenSource struct {
+	config     AppConfig
+	key        *rsa.PrivateKey
+	restURL    string
+	httpClient *http.Client
+	timeout    time.Duration
+	now        func() time.Time
+}
+
+func newAppTokenSource(config AppConfig, restURL string, httpClient *http.Client) (*appTokenSource, error) {
+	if config.InstallationID == 0 {
+		return nil, fmt.Errorf("GitHub App installation_id not configured")
+	}
+
+	data, err := os.ReadFile(config.PrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GitHub App private key: %w", err)
+	}
+	key, err := parseRSAPrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &appTokenSource{
+		config:     config,
+		key:        key,
+		restURL:    restURL,
+		httpClient: httpClient,
+		timeout:    installationTokenTimeout,
+		now:        time.Now,
+	}, nil
+}
+
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("GitHub App private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub App private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("GitHub App private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// jwt mints the RS256 token that authenticates as the app itself.
+func (s *appTokenSource) jwt() (string, error) {
+	now := s.now()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-appJWTBackdate).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(s.config.AppID, 10),
+	})
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App JWT: %w", err)
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Token exchanges a fresh app JWT for an installation access token.
+func (s *appTokenSource) Token() (*oauth2.Token, error) {
+	jwt, err := s.jwt()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	url := fmt.Sprintf("%s/app/installations/%d/access_tokens", s.restURL, s.config.InstallationID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request installation token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("failed to request installation token: GitHub returned %s", resp.Status)
+	}
+
+	var body struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode installation token: %w", err)
+	}
+
+	return &oauth2.Token{
+		AccessToken: body.Token,
+		Expiry:      body.ExpiresAt,
+	}, nil
+}
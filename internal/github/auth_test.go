@@ -0,0 +1,138 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func writeTestKey(t *testing.T) (*rsa.PrivateKey, string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "app.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	return key, path
+}
+
+func TestAppTokenSource(t *testing.T) {
+	key, keyFile := writeTestKey(t)
+
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/app/installations/42/access_tokens", r.URL.Path)
+
+		jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		parts := strings.Split(jwt, ".")
+		require.Len(t, parts, 3)
+
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		require.NoError(t, err)
+		require.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+
+		payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+		var claims struct {
+			Iss string `json:"iss"`
+			Iat int64  `json:"iat"`
+			Exp int64  `json:"exp"`
+		}
+		require.NoError(t, json.Unmarshal(payload, &claims))
+		require.Equal(t, "1234", claims.Iss)
+		require.LessOrEqual(t, claims.Exp-claims.Iat, int64(10*time.Minute/time.Second))
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"token": "ghs_installation", "expires_at": "` + time.Now().Add(time.Hour).UTC().Format(time.RFC3339) + `"}`))
+	}))
+	defer srv.Close()
+
+	config := AppConfig{AppID: 1234, InstallationID: 42, PrivateKeyFile: keyFile}
+	src, err := newAppTokenSource(config, srv.URL, srv.Client())
+	require.NoError(t, err)
+
+	reuse := oauth2.ReuseTokenSourceWithExpiry(nil, src, installationTokenEarlyExpiry)
+	for i := 0; i < 2; i++ {
+		token, err := reuse.Token()
+		require.NoError(t, err)
+		require.Equal(t, "ghs_installation", token.AccessToken)
+	}
+	require.Equal(t, 1, requests, "installation token should be reused until it nears expiry")
+}
+
+func TestAppTokenSourceErrors(t *testing.T) {
+	_, keyFile := writeTestKey(t)
+
+	t.Run("missing installation", func(t *testing.T) {
+		_, err := newAppTokenSource(AppConfig{AppID: 1, PrivateKeyFile: keyFile}, DefaultRESTURL, http.DefaultClient)
+		require.ErrorContains(t, err, "installation_id")
+	})
+
+	t.Run("missing key file", func(t *testing.T) {
+		_, err := newAppTokenSource(AppConfig{AppID: 1, InstallationID: 2, PrivateKeyFile: filepath.Join(t.TempDir(), "missing.pem")}, DefaultRESTURL, http.DefaultClient)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := parseRSAPrivateKey([]byte("not a key"))
+		require.ErrorContains(t, err, "not PEM encoded")
+	})
+
+	t.Run("rejected exchange", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer srv.Close()
+
+		src, err := newAppTokenSource(AppConfig{AppID: 1, InstallationID: 2, PrivateKeyFile: keyFile}, srv.URL, srv.Client())
+		require.NoError(t, err)
+
+		_, err = src.Token()
+		require.ErrorContains(t, err, "401")
+	})
+
+	t.Run("unresponsive exchange", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer srv.Close()
+
+		src, err := newAppTokenSource(AppConfig{AppID: 1, InstallationID: 2, PrivateKeyFile: keyFile}, srv.URL, srv.Client())
+		require.NoError(t, err)
+		src.timeout = 50 * time.Millisecond
+
+		_, err = src.Token()
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestNewTokenSourceStatic(t *testing.T) {
+	src, err := NewTokenSource(HostConfig{Token: "ghp_static"}, http.DefaultTransport)
+	require.NoError(t, err)
+
+	token, err := src.Token()
+	require.NoError(t, err)
+	require.Equal(t, "ghp_static", token.AccessToken)
+}
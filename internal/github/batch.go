@@ -0,0 +1,157 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sync"
+
+	"github.com/shurcooL/githubv4"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+const (
+	DefaultBatchSize = 20
+
+	// GitHub refuses queries that may return more than 500,000 nodes.
+	maxNodesPerQuery = 500000
+	// Upper bound on the rate-limit points a single batched query may cost.
+	// GitHub charges one point per 100 connection requests.
+	maxCostPerQuery = 100
+)
+
+// BatchSize returns how many repositories fit into one batched query, taking
+// the configured size and GitHub's node and cost limits into account.
+func (c *Client) BatchSize() int {
+	size := c.batchSize
+	if size <= 0 {
+		size = DefaultBatchSize
+	}
+	if limit := maxNodesPerQuery / metrics.RepositoryQueryNodes; size > limit {
+		size = limit
+	}
+	if limit := maxCostPerQuery * 100 / metrics.RepositoryQueryConnections; size > limit {
+		size = limit
+	}
+	return size
+}
+
+// CollectBatchMetrics fetches several repositories using aliased repository
+// fields, one GraphQL document per batch. Results and errors are parallel to
+// repoFullNames.
+func (c *Client) CollectBatchMetrics(ctx context.Context, repoFullNames []string) ([]*metrics.Repository, []error) {
+	results := make([]*metrics.Repository, len(repoFullNames))
+	errs := make([]error, len(repoFullNames))
+
+	refs := make([]Reference, len(repoFullNames))
+
+	var pending []int
+	for i, repoFullName := range repoFullNames {
+		ref, err := ParseReference(repoFullName)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		if result, found := c.getCached(ref); found {
+			results[i] = result
+			continue
+		}
+		refs[i] = ref
+		pending = append(pending, i)
+	}
+
+	for _, batch := range chunk(pending, c.BatchSize()) {
+		c.collectBatch(ctx, refs, batch, results, errs)
+	}
+
+	return results, errs
+}
+
+func (c *Client) collectBatch(ctx context.Context, refs []Reference, batch []int, results []*metrics.Repository, errs []error) {
+	fields := make([]reflect.StructField, len(batch), len(batch)+1)
+	since := c.historySince()
+	variables := make(map[string]interface{}, 2*len(batch)+1)
+	variables[metrics.VarSince] = since
+	for j, i := range batch {
+		ownerVar := fmt.Sprintf("%s%d", metrics.VarOwner, j)
+		nameVar := fmt.Sprintf("%s%d", metrics.VarName, j)
+		variables[ownerVar] = githubv4.String(refs[i].Owner)
+		variables[nameVar] = githubv4.String(refs[i].Name)
+
+		fields[j] = reflect.StructField{
+			Name: fmt.Sprintf("R%d", j),
+			Type: reflect.TypeOf((*metrics.RepositoryGraphQL)(nil)),
+			Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"r%d: repository(owner: $%s, name: $%s)"`, j, ownerVar, nameVar)),
+		}
+	}
+
+	fields = append(fields, reflect.StructField{
+		Name: "RateLimit",
+		Type: reflect.TypeOf(metrics.RateLimit{}),
+	})
+
+	query := reflect.New(reflect.StructOf(fields))
+	rateLimit := query.Elem().Field(len(batch)).Addr().Interface().(*metrics.RateLimit)
+	queryErr := c.query(ctx, query.Interface(), variables, rateLimit)
+	// When the request itself failed, every entry shares its error and
+	// fetching them one by one would only repeat it.
+	requestErr := queryErr != nil && (ctx.Err() != nil || requestFailed(queryErr))
+
+	// Each entry still needs several REST calls, so entries are completed in
+	// parallel rather than one after another; collectRESTMetrics bounds how
+	// many make those calls at once.
+	var wg sync.WaitGroup
+	for j, i := range batch {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repo, _ := query.Elem().Field(j).Interface().(*metrics.RepositoryGraphQL)
+			if repo != nil {
+				if err := c.completeHistory(ctx, refs[i], since, repo); err != nil {
+					errs[i] = err
+					return
+				}
+				result := c.buildRepository(repo, since.Time)
+				partial, err := c.collectRESTMetrics(ctx, refs[i], result)
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				results[i] = result
+				c.storeResult(refs[i], results[i], partial)
+				return
+			}
+
+			if requestErr {
+				errs[i] = newRepositoryError(refs[i].FullName(), fmt.Errorf("failed to fetch repository data: %w", queryErr))
+				return
+			}
+			// GraphQL errors only carry the first message, so entries that did
+			// not resolve are fetched on their own to get an accurate error.
+			results[i], errs[i] = c.fetchRepository(ctx, refs[i])
+		}()
+	}
+	wg.Wait()
+}
+
+// requestFailed reports whether err is an HTTP or network failure of the
+// request, as opposed to GraphQL errors in a response. The HTTP client wraps
+// every failure of the former kind in a *url.Error.
+func requestFailed(err error) bool {
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+func chunk[T any](items []T, size int) [][]T {
+	var chunks [][]T
+	for size < len(items) {
+		items, chunks = items[size:], append(chunks, items[:size:size])
+	}
+	if len(items) > 0 {
+		chunks = append(chunks, items)
+	}
+	return chunks
+}
@@ -0,0 +1,233 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	return newRESTTestClient(t, handler, defaultREST)
+}
+
+// defaultREST answers REST API calls like GitHub does for a repository without
+// commit activity, branch protection or security advisories.
+func defaultREST(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/stats/participation"):
+		_, _ = w.Write([]byte(`{"all": []}`))
+	case strings.Contains(r.URL.Path, "/rules/branches/"), strings.HasSuffix(r.URL.Path, "/security-advisories"):
+		_, _ = w.Write([]byte(`[]`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// newRESTTestClient serves GraphQL queries with graphql and REST API calls,
+// under /repos/, with rest.
+func newRESTTestClient(t *testing.T, graphql, rest http.HandlerFunc) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/", rest)
+	mux.HandleFunc("/", graphql)
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	httpClient := srv.Client()
+	httpClient.Transport = &statusTransport{base: httpClient.Transport}
+
+	client := NewClient("test-token")
+	client.graphqlClient = githubv4.NewEnterpriseClient(srv.URL, httpClient)
+	client.httpClient = httpClient
+	client.restURL = srv.URL
+	return client
+}
+
+func TestCollectBatchMetrics(t *testing.T) {
+	var queries []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query string `json:"query"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		queries = append(queries, body.Query)
+
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(body.Query, "r0: repository") {
+			_, _ = w.Write([]byte(`{
+				"data": {
+					"r0": {"owner": {"login": "test"}, "name": "repo1", "stargazerCount": 10},
+					"r1": null
+				},
+				"errors": [{"message": "Could not resolve to a Repository with the name 'test/missing'."}]
+			}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{
+			"data": {"repository": null},
+			"errors": [{"message": "Could not resolve to a Repository with the name 'test/missing'."}]
+		}`))
+	})
+
+	repos, errs := client.CollectBatchMetrics(context.Background(), []string{"test/repo1", "test/missing", "invalid"})
+
+	require.Len(t, repos, 3)
+	require.Len(t, errs, 3)
+
+	require.NoError(t, errs[0])
+	require.Equal(t, "repo1", repos[0].Name)
+	require.Equal(t, 10, repos[0].Stars)
+
+	require.Nil(t, repos[1])
+	require.ErrorContains(t, errs[1], "test/missing")
+
+	require.Nil(t, repos[2])
+	require.ErrorContains(t, errs[2], "invalid repository reference")
+
+	require.Len(t, queries, 2, "expected one batched query and one fallback query")
+	require.Contains(t, queries[0], "r0: repository(owner: $owner0, name: $name0)")
+	require.Contains(t, queries[0], "r1: repository(owner: $owner1, name: $name1)")
+	require.Contains(t, queries[0], "rateLimit{cost,limit,remaining,resetAt}")
+	require.Contains(t, queries[1], "rateLimit{cost,limit,remaining,resetAt}")
+}
+
+func TestCollectBatchMetricsRequestFailure(t *testing.T) {
+	queries := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries++
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message": "Bad credentials"}`))
+	})
+
+	repos, errs := client.CollectBatchMetrics(context.Background(), []string{"test/repo1", "test/repo2"})
+
+	require.Equal(t, 1, queries, "a failed request should not be repeated per repository")
+	for i, repo := range []string{"test/repo1", "test/repo2"} {
+		require.Nil(t, repos[i])
+		var ghErr *Error
+		require.ErrorAs(t, errs[i], &ghErr)
+		require.Equal(t, CodeAccessDenied, ghErr.Code)
+		require.Equal(t, repo, ghErr.Repository)
+	}
+}
+
+func TestCollectBatchMetricsParallelREST(t *testing.T) {
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	both := make(chan struct{})
+	go func() {
+		arrived.Wait()
+		close(both)
+	}()
+
+	client := newRESTTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"data": {
+				"r0": {"owner": {"login": "test"}, "name": "repo1"},
+				"r1": {"owner": {"login": "test"}, "name": "repo2"}
+			}
+		}`))
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/stats/participation") {
+			arrived.Done()
+			select {
+			case <-both:
+			case <-time.After(5 * time.Second):
+				http.Error(w, "repositories were collected one after another", http.StatusInternalServerError)
+				return
+			}
+		}
+		defaultREST(w, r)
+	})
+	client.SetConcurrency(2)
+
+	repos, errs := client.CollectBatchMetrics(context.Background(), []string{"test/repo1", "test/repo2"})
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+	require.Equal(t, "repo1", repos[0].Name)
+	require.Equal(t, "repo2", repos[1].Name)
+}
+
+func TestCollectBatchMetricsSharedRESTLimit(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	client := newRESTTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"data": {
+				"r0": {"owner": {"login": "test"}, "name": "repo1"},
+				"r1": {"owner": {"login": "test"}, "name": "repo2"},
+				"r2": {"owner": {"login": "test"}, "name": "repo3"}
+			}
+		}`))
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/stats/participation") {
+			n := inFlight.Add(1)
+			for {
+				if p := peak.Load(); n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			inFlight.Add(-1)
+		}
+		defaultREST(w, r)
+	})
+	client.SetConcurrency(2)
+
+	// Batches analyzed side by side share the client's limit.
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs := client.CollectBatchMetrics(context.Background(), []string{"test/repo1", "test/repo2", "test/repo3"})
+			for _, err := range errs {
+				require.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+	require.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func TestClientBatchSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		batchSize int
+		want      int
+	}{
+		{name: "default", batchSize: 0, want: DefaultBatchSize},
+		{name: "configured", batchSize: 5, want: 5},
+		{name: "capped by cost limit", batchSize: 100000, want: maxCostPerQuery * 100 / metrics.RepositoryQueryConnections},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient("test-token")
+			client.SetBatchSize(tt.batchSize)
+			require.Equal(t, tt.want, client.BatchSize())
+		})
+	}
+}
+
+func TestChunk(t *testing.T) {
+	require.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, chunk([]int{1, 2, 3, 4, 5}, 2))
+	require.Equal(t, [][]int{{1, 2}}, chunk([]int{1, 2}, 5))
+	require.Empty(t, chunk([]int{}, 3))
+}
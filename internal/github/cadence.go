@@ -0,0 +1,33 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// weeklyCommits returns the number of default-branch commits in each of the
+// last 52 weeks, oldest first. It returns nil when the repository is empty,
+// and reports pending while GitHub is still computing the statistics.
+func (c *Client) weeklyCommits(ctx context.Context, ref Reference) ([]int, bool, error) {
+	var participation struct {
+		All []int `json:"all"`
+	}
+
+	err := c.restGet(ctx, fmt.Sprintf("/repos/%s/%s/stats/participation", ref.Owner, ref.Name), &participation)
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusAccepted:
+			return nil, true, nil
+		case http.StatusNoContent:
+			return nil, false, nil
+		}
+	}
+	if err != nil {
+		return nil, false, newRepositoryError(ref.FullName(), fmt.Errorf("failed to fetch commit activity: %w", err))
+	}
+
+	return participation.All, false, nil
+}
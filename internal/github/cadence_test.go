@@ -0,0 +1,94 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdimtriCP/gh-inspector/internal/cache"
+)
+
+func TestWeeklyCommits(t *testing.T) {
+	graphql := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"repository": {"owner": {"login": "test"}, "name": "repo"}}}`))
+	}
+
+	t.Run("participation", func(t *testing.T) {
+		var paths []string
+		client := newRESTTestClient(t, graphql, func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasSuffix(r.URL.Path, "/stats/participation") {
+				defaultREST(w, r)
+				return
+			}
+			paths = append(paths, r.URL.Path)
+			_, _ = w.Write([]byte(`{"all": [0, 3, 5], "owner": [0, 1, 2]}`))
+		})
+
+		repo, err := client.CollectBasicMetrics(context.Background(), "test/repo")
+		require.NoError(t, err)
+		require.Equal(t, []int{0, 3, 5}, repo.WeeklyCommits)
+		require.Equal(t, []string{"/repos/test/repo/stats/participation"}, paths)
+	})
+
+	t.Run("still computing", func(t *testing.T) {
+		attempts := 0
+		client := newRESTTestClient(t, graphql, func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasSuffix(r.URL.Path, "/stats/participation") {
+				defaultREST(w, r)
+				return
+			}
+			attempts++
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{}`))
+		})
+		client.SetRetryConfig(RetryConfig{MaxAttempts: 4, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+		c, err := cache.New(t.TempDir())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = c.Close() })
+		client.SetCache(c)
+
+		repo, err := client.CollectBasicMetrics(context.Background(), "test/repo")
+		require.NoError(t, err)
+		require.Nil(t, repo.WeeklyCommits)
+		require.Equal(t, 4, attempts, "pending statistics should be asked for up to the retry attempts")
+
+		_, found := client.getCached(Reference{Host: DefaultHost, Owner: "test", Name: "repo"})
+		require.False(t, found, "results without pending statistics should not be cached")
+	})
+
+	t.Run("computed meanwhile", func(t *testing.T) {
+		attempts := 0
+		client := newRESTTestClient(t, graphql, func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasSuffix(r.URL.Path, "/stats/participation") {
+				defaultREST(w, r)
+				return
+			}
+			attempts++
+			if attempts == 1 {
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = w.Write([]byte(`{}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"all": [1, 2]}`))
+		})
+
+		repo, err := client.CollectBasicMetrics(context.Background(), "test/repo")
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2}, repo.WeeklyCommits)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		client := newRESTTestClient(t, graphql, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		})
+
+		_, err := client.CollectBasicMetrics(context.Background(), "test/repo")
+		require.ErrorIs(t, err, ErrAccessDenied)
+	})
+}
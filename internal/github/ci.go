@@ -0,0 +1,249 @@
+package github
+
+import (
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+const providerGitHubActions = "GitHub Actions"
+
+// ciProviders maps root entries, lower-cased, to the CI service they
+// configure.
+var ciProviders = map[string]string{
+	metrics.CIGitLab:      "GitLab CI",
+	metrics.CICircleCI:    "CircleCI",
+	metrics.CITravis:      "Travis CI",
+	metrics.CIJenkins:     "Jenkins",
+	metrics.CIAzure:       "Azure Pipelines",
+	metrics.CIDrone:       "Drone",
+	metrics.CIBitbucket:   "Bitbucket Pipelines",
+	metrics.CIAppVeyor:    "AppVeyor",
+	metrics.CIAppVeyorAlt: "AppVeyor",
+	metrics.CIBuildkite:   "Buildkite",
+}
+
+var renovateConfigs = []string{"renovate.json", "renovate.json5", ".renovaterc", ".renovaterc.json", ".renovaterc.json5"}
+
+// Actions that set up or tidy a job without building or testing anything, as
+// path.Match patterns for the action name without its ref.
+var setupActions = []string{
+	"actions/checkout",
+	"actions/cache",
+	"actions/cache/*",
+	"actions/download-artifact",
+	"actions/upload-artifact",
+	"actions/setup-*",
+}
+
+// Actions that triage issues and pull requests or manage dependencies.
+var botActions = []string{
+	"actions/stale",
+	"actions/labeler",
+	"actions/first-interaction",
+	"dependabot/fetch-metadata",
+	"release-drafter/release-drafter",
+	"peter-evans/*",
+}
+
+var codeQLActions = []string{"github/codeql-action/*"}
+
+type workflowKind int
+
+const (
+	workflowTest workflowKind = iota
+	workflowRelease
+	workflowBot
+	workflowAnalysis
+	workflowInvalid
+)
+
+type ciSummary struct {
+	providers        []string
+	testWorkflows    int
+	releaseWorkflows int
+	botWorkflows     int
+	dependabot       bool
+	renovate         bool
+	codeQL           bool
+}
+
+// ciStats inspects the CI configuration of a repository: CI services
+// configured in the root, GitHub Actions workflows by purpose, and whether
+// Dependabot, Renovate and CodeQL are set up.
+func ciStats(root, githubDir []metrics.TreeEntry, workflows []metrics.FileEntry) ciSummary {
+	var summary ciSummary
+
+	for _, entry := range workflows {
+		name := strings.ToLower(string(entry.Name))
+		if ext := path.Ext(name); ext != ".yml" && ext != ".yaml" {
+			continue
+		}
+		if entry.Object == nil || entry.Object.Blob.Text == nil {
+			continue
+		}
+
+		kind, codeQL := classifyWorkflow(string(*entry.Object.Blob.Text))
+		summary.codeQL = summary.codeQL || codeQL
+		switch kind {
+		case workflowTest:
+			summary.testWorkflows++
+		case workflowRelease:
+			summary.releaseWorkflows++
+		case workflowBot:
+			summary.botWorkflows++
+		}
+	}
+	if summary.testWorkflows > 0 {
+		summary.providers = append(summary.providers, providerGitHubActions)
+	}
+
+	seen := make(map[string]bool)
+	for _, entry := range root {
+		name := strings.ToLower(string(entry.Name))
+		if provider, ok := ciProviders[name]; ok && !seen[provider] {
+			seen[provider] = true
+			summary.providers = append(summary.providers, provider)
+		}
+		summary.renovate = summary.renovate || isRenovateConfig(name)
+	}
+
+	for _, entry := range githubDir {
+		name := strings.ToLower(string(entry.Name))
+		switch {
+		case name == metrics.FileDependabot || name == metrics.FileDependabotAlt:
+			summary.dependabot = true
+		case name == metrics.DirCodeQL:
+			summary.codeQL = true
+		case isRenovateConfig(name):
+			summary.renovate = true
+		}
+	}
+
+	return summary
+}
+
+func isRenovateConfig(name string) bool {
+	for _, config := range renovateConfigs {
+		if name == config {
+			return true
+		}
+	}
+	return false
+}
+
+type workflowFile struct {
+	On   yaml.Node `yaml:"on"`
+	Jobs map[string]struct {
+		Uses  string `yaml:"uses"`
+		Steps []struct {
+			Uses string `yaml:"uses"`
+			Run  string `yaml:"run"`
+		} `yaml:"steps"`
+	} `yaml:"jobs"`
+}
+
+// classifyWorkflow tells test and build workflows, which run on pushes to
+// branches or on pull requests and do more than check out code or call bot
+// actions, apart from release workflows triggered by releases or tags and
+// from bots reacting to schedules, issues and comments. It also reports
+// whether the workflow runs CodeQL. Only triggers and job steps are looked
+// at; workflow, job and file names are not.
+func classifyWorkflow(content string) (workflowKind, bool) {
+	var workflow workflowFile
+	if err := yaml.Unmarshal([]byte(content), &workflow); err != nil || len(workflow.Jobs) == 0 {
+		return workflowInvalid, false
+	}
+
+	codeQL, substantive := false, false
+	for _, job := range workflow.Jobs {
+		if job.Uses != "" {
+			// Reusable workflows are assumed to build or test.
+			substantive = true
+		}
+		for _, step := range job.Steps {
+			action := actionName(step.Uses)
+			switch {
+			case matchesAny(action, codeQLActions):
+				codeQL = true
+			case step.Run != "":
+				substantive = true
+			case action != "" && !matchesAny(action, setupActions) && !matchesAny(action, botActions):
+				substantive = true
+			}
+		}
+	}
+
+	triggers := workflowTriggers(&workflow.On)
+	onBranches := triggers["pull_request"] || triggers["merge_group"] || triggers["push"]
+	switch {
+	case onBranches && substantive:
+		return workflowTest, codeQL
+	case triggers["release"] || triggers["tags"]:
+		return workflowRelease, codeQL
+	case codeQL:
+		return workflowAnalysis, codeQL
+	default:
+		return workflowBot, codeQL
+	}
+}
+
+// workflowTriggers lists the events a workflow runs on. A push limited to tags
+// is reported as "tags" instead of "push".
+func workflowTriggers(on *yaml.Node) map[string]bool {
+	triggers := make(map[string]bool)
+	switch on.Kind {
+	case yaml.ScalarNode:
+		triggers[on.Value] = true
+	case yaml.SequenceNode:
+		for _, event := range on.Content {
+			triggers[event.Value] = true
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(on.Content); i += 2 {
+			event, config := on.Content[i].Value, on.Content[i+1]
+			if event == "push" && tagsOnly(config) {
+				event = "tags"
+			}
+			triggers[event] = true
+		}
+	}
+	return triggers
+}
+
+func tagsOnly(push *yaml.Node) bool {
+	if push.Kind != yaml.MappingNode {
+		return false
+	}
+
+	tags, branches := false, false
+	for i := 0; i+1 < len(push.Content); i += 2 {
+		switch push.Content[i].Value {
+		case "tags", "tags-ignore":
+			tags = true
+		case "branches", "branches-ignore":
+			branches = true
+		}
+	}
+	return tags && !branches
+}
+
+// actionName returns the lower-cased action a step uses, without its ref.
+func actionName(uses string) string {
+	name, _, _ := strings.Cut(uses, "@")
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// matchesAny reports whether an action name matches one of the patterns as a
+// whole, so "actions/checkout" does not match "actions/checkout-latest".
+func matchesAny(action string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, action); ok {
+			return true
+		}
+	}
+	return false
+}
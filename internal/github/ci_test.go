@@ -0,0 +1,149 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+const (
+	testWorkflow = `
+name: CI
+on:
+  push:
+    branches: [main]
+  pull_request:
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@v5
+      - run: go test ./...
+`
+	releaseWorkflow = `
+on:
+  push:
+    tags: ["v*"]
+jobs:
+  release:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: goreleaser/goreleaser-action@v6
+`
+	staleWorkflow = `
+on:
+  schedule:
+    - cron: "0 0 * * *"
+jobs:
+  stale:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/stale@v9
+`
+	labelerWorkflow = `
+on: [pull_request]
+jobs:
+  label:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/labeler@v5
+`
+	codeQLWorkflow = `
+on:
+  push:
+    branches: [main]
+  pull_request:
+jobs:
+  analyze:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: github/codeql-action/init@v3
+      - uses: github/codeql-action/analyze@v3
+`
+	reusableWorkflow = `
+on: pull_request
+jobs:
+  build:
+    uses: org/workflows/.github/workflows/go.yml@main
+`
+)
+
+func TestClassifyWorkflow(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		want       workflowKind
+		wantCodeQL bool
+	}{
+		{name: "test", content: testWorkflow, want: workflowTest},
+		{name: "release on tags", content: releaseWorkflow, want: workflowRelease},
+		{name: "release event", content: "on:\n  release:\n    types: [published]\njobs:\n  publish:\n    steps:\n      - run: make publish\n", want: workflowRelease},
+		{name: "scheduled bot", content: staleWorkflow, want: workflowBot},
+		{name: "pull request bot", content: labelerWorkflow, want: workflowBot},
+		{name: "codeql", content: codeQLWorkflow, want: workflowAnalysis, wantCodeQL: true},
+		{name: "reusable workflow", content: reusableWorkflow, want: workflowTest},
+		{name: "names are ignored", content: "name: Test latest\non: [pull_request]\njobs:\n  test-latest:\n    steps:\n      - name: Run tests\n        uses: actions/labeler@v5\n", want: workflowBot},
+		{name: "action names match whole", content: "on: [push]\njobs:\n  build:\n    steps:\n      - uses: actions/checkout-latest@v1\n", want: workflowTest},
+		{name: "action names ignore case and ref", content: "on: [push]\njobs:\n  tidy:\n    steps:\n      - uses: Actions/Checkout@v4\n      - uses: actions/cache/restore@v4\n", want: workflowBot},
+		{name: "invalid yaml", content: "on: [push\n", want: workflowInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, codeQL := classifyWorkflow(tt.content)
+			require.Equal(t, tt.want, kind)
+			require.Equal(t, tt.wantCodeQL, codeQL)
+		})
+	}
+}
+
+func TestCIStats(t *testing.T) {
+	entries := func(names ...string) []metrics.TreeEntry {
+		var result []metrics.TreeEntry
+		for _, name := range names {
+			result = append(result, metrics.TreeEntry{Name: githubv4.String(name)})
+		}
+		return result
+	}
+	workflow := func(name, content string) metrics.FileEntry {
+		text := githubv4.String(content)
+		return metrics.FileEntry{Name: githubv4.String(name), Object: &metrics.FileObject{Blob: metrics.Blob{Text: &text}}}
+	}
+
+	t.Run("issue templates only", func(t *testing.T) {
+		summary := ciStats(entries(".github", "README.md"), entries("ISSUE_TEMPLATE"), nil)
+		require.Empty(t, summary.providers)
+		require.False(t, summary.dependabot)
+	})
+
+	t.Run("full setup", func(t *testing.T) {
+		workflows := []metrics.FileEntry{
+			workflow("ci.yml", testWorkflow),
+			workflow("release.yaml", releaseWorkflow),
+			workflow("stale.yml", staleWorkflow),
+			workflow("codeql.yml", codeQLWorkflow),
+			workflow("README.md", testWorkflow),
+		}
+		summary := ciStats(entries(".github", ".gitlab-ci.yml", ".gitlab", "renovate.json"), entries("dependabot.yml", "workflows"), workflows)
+		require.Equal(t, []string{"GitHub Actions", "GitLab CI"}, summary.providers)
+		require.Equal(t, 1, summary.testWorkflows)
+		require.Equal(t, 1, summary.releaseWorkflows)
+		require.Equal(t, 1, summary.botWorkflows)
+		require.True(t, summary.dependabot)
+		require.True(t, summary.renovate)
+		require.True(t, summary.codeQL)
+	})
+
+	t.Run("release workflows only", func(t *testing.T) {
+		summary := ciStats(entries(".github"), entries("workflows", "renovate.json5"), []metrics.FileEntry{workflow("release.yml", releaseWorkflow)})
+		require.Empty(t, summary.providers)
+		require.Equal(t, 1, summary.releaseWorkflows)
+		require.True(t, summary.renovate)
+	})
+}
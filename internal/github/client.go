@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/shurcooL/githubv4"
@@ -12,21 +13,70 @@ import (
 )
 
 type Client struct {
-	graphqlClient   *githubv4.Client
-	cache           cache.Cache
-	cacheTTL        time.Duration
+	graphqlClient *githubv4.Client
+	httpClient    *http.Client
+	restURL       string
+	host          string
+	cache         cache.Cache
+	cacheTTL      time.Duration
+	batchSize     int
+	// restSlots bounds how many repositories have their REST metrics
+	// collected at once, across every batch of the client.
+	restSlots       chan struct{}
+	historyWindow   time.Duration
+	rateLimiter     *rateLimiter
+	restLimiter     *rateLimiter
+	tokenPool       *tokenPool
+	retryConfig     RetryConfig
 	metricsRecorder metrics.Recorder
 }
 
 func NewClient(token string) *Client {
-	src := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	httpClient := oauth2.NewClient(context.Background(), src)
+	src := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	return newClient(HostConfig{}, &oauth2.Transport{Source: src, Base: http.DefaultTransport})
+}
+
+// NewHostClient creates a client for the GitHub instance described by config.
+// Several tokens are pooled and rotated by remaining rate-limit budget.
+func NewHostClient(config HostConfig) (*Client, error) {
+	transport, err := config.transport()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(config.Tokens) > 0 && !config.App.Enabled() {
+		pool := newTokenPool(config.hostName(), config.Tokens)
+		client := newClient(config, &poolTransport{base: transport, pool: pool})
+		client.tokenPool = pool
+		return client, nil
+	}
+
+	src, err := NewTokenSource(config, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient(config, &oauth2.Transport{Source: src, Base: transport}), nil
+}
+
+func newClient(config HostConfig, transport http.RoundTripper) *Client {
+	restLimiter := newRateLimiter()
+	httpClient := &http.Client{Transport: &statusTransport{
+		base: &restBudgetTransport{base: transport, limiter: restLimiter},
+	}}
 
 	return &Client{
-		graphqlClient:   githubv4.NewClient(httpClient),
+		graphqlClient:   githubv4.NewEnterpriseClient(config.apiURL(), httpClient),
+		httpClient:      httpClient,
+		restURL:         config.restURL(),
+		host:            config.hostName(),
 		cacheTTL:        1 * time.Hour,
+		batchSize:       DefaultBatchSize,
+		restSlots:       make(chan struct{}, DefaultConcurrency),
+		historyWindow:   DefaultHistoryWindow,
+		rateLimiter:     newRateLimiter(),
+		restLimiter:     restLimiter,
+		retryConfig:     DefaultRetryConfig(),
 		metricsRecorder: &metrics.NoOpRecorder{},
 	}
 }
@@ -39,6 +89,70 @@ func (c *Client) SetCacheTTL(ttl time.Duration) {
 	c.cacheTTL = ttl
 }
 
+func (c *Client) SetBatchSize(size int) {
+	c.batchSize = size
+}
+
+// SetConcurrency sets how many repositories have their REST metrics
+// collected in parallel, DefaultConcurrency when it is not positive. It must
+// not be called while repositories are analyzed.
+func (c *Client) SetConcurrency(concurrency int) {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	c.restSlots = make(chan struct{}, concurrency)
+}
+
 func (c *Client) SetMetricsRecorder(recorder metrics.Recorder) {
 	c.metricsRecorder = recorder
+	if c.tokenPool != nil {
+		c.tokenPool.setMetricsRecorder(recorder)
+	}
+}
+
+// query runs a GraphQL query after waiting out any throttling, retrying
+// transient failures, and records the rateLimit block the query filled into
+// state.
+func (c *Client) query(ctx context.Context, q interface{}, variables map[string]interface{}, state *metrics.RateLimit) error {
+	maxAttempts := max(c.retryConfig.MaxAttempts, 1)
+
+	for attempt := 1; ; attempt++ {
+		*state = metrics.RateLimit{}
+
+		waited, err := c.waitForBudget(ctx, resourceGraphQL)
+		if err != nil {
+			return err
+		}
+		if waited > 0 {
+			c.metricsRecorder.RecordRateLimitWait(waited)
+		}
+
+		err = c.graphqlClient.Query(ctx, q, variables)
+
+		if !state.ResetAt.IsZero() {
+			if c.tokenPool == nil {
+				c.rateLimiter.update(*state)
+			}
+			c.metricsRecorder.RecordRateLimit(int(state.Remaining), int(state.Limit), state.ResetAt.Time)
+			c.metricsRecorder.RecordRateLimitCost(int(state.Cost))
+		}
+
+		if err == nil || attempt >= maxAttempts || ctx.Err() != nil {
+			return err
+		}
+
+		reason, transient := classifyError(err)
+		if !transient && c.tokenPool != nil && isUnauthorized(err) {
+			// The failing token is now set aside; another one may succeed.
+			reason, transient = RetryReasonAuthFailure, true
+		}
+		if !transient {
+			return err
+		}
+
+		c.metricsRecorder.RecordRetry(reason)
+		if err := sleepContext(ctx, retryDelay(err, attempt, c.retryConfig)); err != nil {
+			return err
+		}
+	}
 }
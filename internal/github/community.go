@@ -0,0 +1,67 @@
+package github
+
+import (
+	"path"
+	"strings"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+type communityCheck struct {
+	name string
+	// Lower-case file or directory names without extension.
+	names []string
+	// Directories searched, in GitHub's order of precedence. An empty name
+	// is the repository root.
+	dirs []string
+}
+
+var healthFileDirs = []string{metrics.DirGitHub, "", metrics.DirDocs}
+
+var communityChecks = []communityCheck{
+	{name: metrics.CommunityReadme, names: []string{"readme"}, dirs: healthFileDirs},
+	{name: metrics.CommunityContributing, names: []string{"contributing"}, dirs: healthFileDirs},
+	{name: metrics.CommunityCodeOfConduct, names: []string{"code_of_conduct", "code-of-conduct"}, dirs: healthFileDirs},
+	{name: metrics.CommunitySecurity, names: []string{"security"}, dirs: healthFileDirs},
+	{name: metrics.CommunityIssueTemplate, names: []string{"issue_template"}, dirs: healthFileDirs},
+	{name: metrics.CommunityPRTemplate, names: []string{"pull_request_template"}, dirs: healthFileDirs},
+	{name: metrics.CommunityCodeowners, names: []string{"codeowners"}, dirs: healthFileDirs},
+	{name: metrics.CommunityFunding, names: []string{"funding"}, dirs: []string{metrics.DirGitHub}},
+	{name: metrics.CommunityGovernance, names: []string{"governance"}, dirs: healthFileDirs},
+	{name: metrics.CommunitySupport, names: []string{"support"}, dirs: healthFileDirs},
+}
+
+// communityFiles looks up community health files in the root, .github and
+// docs directories like GitHub's community profile does, and returns the path
+// that satisfied each check found.
+func communityFiles(root, githubDir, docs []metrics.TreeEntry) map[string]string {
+	entries := map[string][]metrics.TreeEntry{
+		"":                root,
+		metrics.DirGitHub: githubDir,
+		metrics.DirDocs:   docs,
+	}
+
+	found := make(map[string]string)
+	for _, check := range communityChecks {
+		if file, ok := findHealthFile(check, entries); ok {
+			found[check.name] = file
+		}
+	}
+	return found
+}
+
+func findHealthFile(check communityCheck, entries map[string][]metrics.TreeEntry) (string, bool) {
+	for _, dir := range check.dirs {
+		for _, entry := range entries[dir] {
+			name := string(entry.Name)
+			lower := strings.ToLower(name)
+			stem := strings.TrimSuffix(lower, path.Ext(lower))
+			for _, want := range check.names {
+				if stem == want {
+					return path.Join(dir, name), true
+				}
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,72 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+func TestCommunityFiles(t *testing.T) {
+	entries := func(names ...string) []metrics.TreeEntry {
+		var result []metrics.TreeEntry
+		for _, name := range names {
+			result = append(result, metrics.TreeEntry{Name: githubv4.String(name)})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name      string
+		root      []metrics.TreeEntry
+		githubDir []metrics.TreeEntry
+		docs      []metrics.TreeEntry
+		want      map[string]string
+	}{
+		{
+			name: "nothing",
+			root: entries("main.go", "security-scanner"),
+			want: map[string]string{},
+		},
+		{
+			name:      "spread over directories",
+			root:      entries("README.md", "CODEOWNERS", "LICENSE", "docs", ".github"),
+			githubDir: entries("CONTRIBUTING.md", "ISSUE_TEMPLATE", "pull_request_template.md", "FUNDING.yml", "workflows"),
+			docs:      entries("CODE_OF_CONDUCT.md", "SECURITY.md", "GOVERNANCE.md", "SUPPORT.rst"),
+			want: map[string]string{
+				metrics.CommunityReadme:        "README.md",
+				metrics.CommunityContributing:  ".github/CONTRIBUTING.md",
+				metrics.CommunityCodeOfConduct: "docs/CODE_OF_CONDUCT.md",
+				metrics.CommunitySecurity:      "docs/SECURITY.md",
+				metrics.CommunityIssueTemplate: ".github/ISSUE_TEMPLATE",
+				metrics.CommunityPRTemplate:    ".github/pull_request_template.md",
+				metrics.CommunityCodeowners:    "CODEOWNERS",
+				metrics.CommunityFunding:       ".github/FUNDING.yml",
+				metrics.CommunityGovernance:    "docs/GOVERNANCE.md",
+				metrics.CommunitySupport:       "docs/SUPPORT.rst",
+			},
+		},
+		{
+			name:      ".github takes precedence",
+			root:      entries("README.md", "code-of-conduct.md"),
+			githubDir: entries("README.md"),
+			want: map[string]string{
+				metrics.CommunityReadme:        ".github/README.md",
+				metrics.CommunityCodeOfConduct: "code-of-conduct.md",
+			},
+		},
+		{
+			name: "funding only counts in .github",
+			root: entries("FUNDING.yml"),
+			want: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, communityFiles(tt.root, tt.githubDir, tt.docs))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+const (
+	DefaultHistoryWindow = 90 * 24 * time.Hour
+
+	// Contributor metrics are computed from at most this many pages of 100
+	// commits, the most recent ones in the window.
+	maxHistoryPages = 10
+)
+
+func (c *Client) SetHistoryWindow(window time.Duration) {
+	c.historyWindow = window
+}
+
+func (c *Client) historySince() githubv4.GitTimestamp {
+	window := c.historyWindow
+	if window <= 0 {
+		window = DefaultHistoryWindow
+	}
+	return githubv4.GitTimestamp{Time: time.Now().Add(-window).UTC().Truncate(time.Second)}
+}
+
+// completeHistory fetches the pages of recent commit history that did not fit
+// into the repository query, so contributor metrics see the whole window.
+func (c *Client) completeHistory(ctx context.Context, ref Reference, since githubv4.GitTimestamp, repo *metrics.RepositoryGraphQL) error {
+	if repo.DefaultBranchRef == nil {
+		return nil
+	}
+	recent := &repo.DefaultBranchRef.Target.Commit.Recent
+
+	for page := 1; page < maxHistoryPages && recent.PageInfo.HasNextPage; page++ {
+		var query metrics.HistoryPageQuery
+		variables := map[string]interface{}{
+			metrics.VarOwner:  githubv4.String(ref.Owner),
+			metrics.VarName:   githubv4.String(ref.Name),
+			metrics.VarSince:  since,
+			metrics.VarCursor: recent.PageInfo.EndCursor,
+		}
+		if err := c.query(ctx, &query, variables, &query.RateLimit); err != nil {
+			return newRepositoryError(ref.FullName(), fmt.Errorf("failed to fetch commit history: %w", err))
+		}
+		if query.Repository.DefaultBranchRef == nil {
+			break
+		}
+
+		next := query.Repository.DefaultBranchRef.Target.Commit.History
+		recent.Nodes = append(recent.Nodes, next.Nodes...)
+		recent.PageInfo = next.PageInfo
+	}
+	return nil
+}
+
+// contributorStats counts distinct commit authors and derives the top
+// author's share of commits and the bus factor: the fewest authors that
+// together made at least half of the commits. Bot commits are ignored.
+func contributorStats(commits []metrics.AuthoredCommit) (contributors int, topShare float64, busFactor int) {
+	counts := make(map[string]int)
+	total := 0
+	for _, commit := range commits {
+		author := commitAuthorKey(commit.Author)
+		if author == "" || strings.Contains(author, "[bot]") {
+			continue
+		}
+		counts[author]++
+		total++
+	}
+	if total == 0 {
+		return 0, 0, 0
+	}
+
+	perAuthor := make([]int, 0, len(counts))
+	for _, n := range counts {
+		perAuthor = append(perAuthor, n)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(perAuthor)))
+
+	covered := 0
+	for _, n := range perAuthor {
+		busFactor++
+		covered += n
+		if covered*2 >= total {
+			break
+		}
+	}
+
+	return len(perAuthor), float64(perAuthor[0]) / float64(total), busFactor
+}
+
+// commitAuthorKey identifies an author by GitHub login, falling back to the
+// git email and name for commits not linked to an account.
+func commitAuthorKey(author metrics.CommitAuthor) string {
+	if author.User != nil && author.User.Login != "" {
+		return strings.ToLower(string(author.User.Login))
+	}
+	if author.Email != "" {
+		return strings.ToLower(string(author.Email))
+	}
+	return strings.ToLower(string(author.Name))
+}
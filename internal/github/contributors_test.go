@@ -0,0 +1,121 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+func commitsBy(logins ...string) []metrics.AuthoredCommit {
+	commits := make([]metrics.AuthoredCommit, 0, len(logins))
+	for _, login := range logins {
+		commits = append(commits, metrics.AuthoredCommit{
+			Author: metrics.CommitAuthor{User: &metrics.CommitAuthorUser{Login: githubv4.String(login)}},
+		})
+	}
+	return commits
+}
+
+func TestContributorStats(t *testing.T) {
+	tests := []struct {
+		name             string
+		commits          []metrics.AuthoredCommit
+		wantContributors int
+		wantTopShare     float64
+		wantBusFactor    int
+	}{
+		{name: "no commits"},
+		{
+			name:             "single author",
+			commits:          commitsBy("alice", "alice", "Alice"),
+			wantContributors: 1,
+			wantTopShare:     1,
+			wantBusFactor:    1,
+		},
+		{
+			name:             "even team",
+			commits:          commitsBy("alice", "bob", "carol", "dave"),
+			wantContributors: 4,
+			wantTopShare:     0.25,
+			wantBusFactor:    2,
+		},
+		{
+			name:             "dominant author",
+			commits:          commitsBy("alice", "alice", "alice", "bob", "carol", "dave"),
+			wantContributors: 4,
+			wantTopShare:     0.5,
+			wantBusFactor:    1,
+		},
+		{
+			name: "bots and unlinked authors",
+			commits: append(commitsBy("alice"),
+				metrics.AuthoredCommit{Author: metrics.CommitAuthor{Name: "dependabot[bot]", Email: "49699333+dependabot[bot]@users.noreply.github.com"}},
+				metrics.AuthoredCommit{Author: metrics.CommitAuthor{Name: "Bob", Email: "Bob@example.com"}},
+				metrics.AuthoredCommit{Author: metrics.CommitAuthor{Name: "Bob", Email: "bob@example.com"}},
+			),
+			wantContributors: 2,
+			wantTopShare:     2.0 / 3.0,
+			wantBusFactor:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contributors, topShare, busFactor := contributorStats(tt.commits)
+			require.Equal(t, tt.wantContributors, contributors)
+			require.InDelta(t, tt.wantTopShare, topShare, 0.0001)
+			require.Equal(t, tt.wantBusFactor, busFactor)
+		})
+	}
+}
+
+func TestCollectBasicMetricsHistoryPages(t *testing.T) {
+	var cursors []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Contains(t, body.Variables, metrics.VarSince)
+
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.Contains(body.Query, "after: $cursor") {
+			_, _ = w.Write([]byte(`{"data": {"repository": {
+				"owner": {"login": "test"}, "name": "repo",
+				"defaultBranchRef": {"target": {
+					"history": {"edges": []},
+					"recent": {
+						"totalCount": 3,
+						"pageInfo": {"hasNextPage": true, "endCursor": "c1"},
+						"nodes": [{"author": {"user": {"login": "alice"}}}, {"author": {"user": {"login": "alice"}}}]
+					}
+				}}
+			}}}`))
+			return
+		}
+
+		cursors = append(cursors, fmt.Sprint(body.Variables[metrics.VarCursor]))
+		_, _ = w.Write([]byte(`{"data": {"repository": {"defaultBranchRef": {"target": {"history": {
+			"totalCount": 3,
+			"pageInfo": {"hasNextPage": false, "endCursor": "c2"},
+			"nodes": [{"author": {"user": {"login": "bob"}}}]
+		}}}}}}`))
+	})
+
+	repo, err := client.CollectBasicMetrics(context.Background(), "test/repo")
+	require.NoError(t, err)
+	require.Equal(t, []string{"c1"}, cursors)
+	require.Equal(t, 3, repo.CommitCount)
+	require.Equal(t, 2, repo.ContributorCount)
+	require.InDelta(t, 2.0/3.0, repo.TopContributorShare, 0.0001)
+	require.Equal(t, 1, repo.BusFactor)
+}
@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+type OwnerKind string
+
+const (
+	OwnerOrganization OwnerKind = "Organization"
+	OwnerUser         OwnerKind = "User"
+)
+
+func (k OwnerKind) describe() string {
+	if k == OwnerOrganization {
+		return "an organization"
+	}
+	return "a user"
+}
+
+// DiscoveryFilter selects which of an owner's repositories are listed.
+// Archived repositories and forks are left out unless included; languages
+// match the primary language and topics match any topic, ignoring case.
+type DiscoveryFilter struct {
+	IncludeArchived bool
+	IncludeForks    bool
+	Languages       []string
+	Topics          []string
+	MinStars        int
+	PushedSince     time.Time
+}
+
+func (f DiscoveryFilter) matches(node metrics.OwnedRepositoryNode) bool {
+	if bool(node.IsArchived) && !f.IncludeArchived {
+		return false
+	}
+	if bool(node.IsFork) && !f.IncludeForks {
+		return false
+	}
+	if int(node.StargazerCount) < f.MinStars {
+		return false
+	}
+	if len(f.Languages) > 0 {
+		if node.PrimaryLanguage == nil || !containsFold(f.Languages, string(node.PrimaryLanguage.Name)) {
+			return false
+		}
+	}
+	if len(f.Topics) > 0 {
+		found := false
+		for _, topic := range node.RepositoryTopics.Nodes {
+			if containsFold(f.Topics, string(topic.Topic.Name)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return f.PushedSince.IsZero() || pushedSince(node, f.PushedSince)
+}
+
+func pushedSince(node metrics.OwnedRepositoryNode, since time.Time) bool {
+	return node.PushedAt != nil && !node.PushedAt.Before(since)
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListRepositories pages through the repositories owned by the organization
+// or user login and returns those matching filter in canonical form, most
+// recently pushed first.
+func (c *Client) ListRepositories(ctx context.Context, kind OwnerKind, login string, filter DiscoveryFilter) ([]string, error) {
+	var repos []string
+	var cursor *githubv4.String
+
+	for {
+		var query metrics.OwnerRepositoriesQuery
+		variables := map[string]interface{}{
+			metrics.VarOwner:  githubv4.String(login),
+			metrics.VarCursor: cursor,
+		}
+		if err := c.query(ctx, &query, variables, &query.RateLimit); err != nil {
+			return nil, newRepositoryError(login, fmt.Errorf("failed to list repositories of %s: %w", login, err))
+		}
+
+		owner := query.RepositoryOwner
+		if owner == nil {
+			return nil, &Error{Code: CodeNotFound, Repository: login, Err: fmt.Errorf("no organization or user named %s", login)}
+		}
+		if OwnerKind(owner.Typename) != kind {
+			return nil, &Error{
+				Code:       CodeInvalidName,
+				Repository: login,
+				Err:        fmt.Errorf("%s is not %s", login, kind.describe()),
+			}
+		}
+
+		for _, node := range owner.Repositories.Nodes {
+			// Repositories come most recently pushed first, so nothing
+			// further down was pushed in time either.
+			if !filter.PushedSince.IsZero() && !pushedSince(node, filter.PushedSince) {
+				return repos, nil
+			}
+			if !filter.matches(node) {
+				continue
+			}
+			ref, err := ParseReference(string(node.NameWithOwner))
+			if err != nil {
+				continue
+			}
+			ref.Host = c.host
+			repos = append(repos, ref.String())
+		}
+
+		if !owner.Repositories.PageInfo.HasNextPage {
+			return repos, nil
+		}
+		next := owner.Repositories.PageInfo.EndCursor
+		cursor = &next
+	}
+}
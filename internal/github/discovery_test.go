@@ -0,0 +1,107 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+func TestListRepositories(t *testing.T) {
+	pages := map[string]string{
+		"": `{"data": {"repositoryOwner": {"__typename": "Organization", "repositories": {
+			"nodes": [
+				{"nameWithOwner": "acme/API", "stargazerCount": 500, "pushedAt": "2024-06-01T00:00:00Z", "primaryLanguage": {"name": "Go"}, "repositoryTopics": {"nodes": [{"topic": {"name": "http"}}]}},
+				{"nameWithOwner": "acme/old", "isArchived": true, "stargazerCount": 900, "pushedAt": "2024-05-01T00:00:00Z", "primaryLanguage": {"name": "Go"}},
+				{"nameWithOwner": "acme/fork", "isFork": true, "stargazerCount": 900, "pushedAt": "2024-04-01T00:00:00Z", "primaryLanguage": {"name": "Go"}}
+			],
+			"pageInfo": {"hasNextPage": true, "endCursor": "page2"}}}}}`,
+		"page2": `{"data": {"repositoryOwner": {"__typename": "Organization", "repositories": {
+			"nodes": [
+				{"nameWithOwner": "acme/web", "stargazerCount": 50, "pushedAt": "2024-03-01T00:00:00Z", "primaryLanguage": {"name": "TypeScript"}},
+				{"nameWithOwner": "acme/cli", "stargazerCount": 10, "pushedAt": "2023-01-01T00:00:00Z", "primaryLanguage": {"name": "go"}}
+			],
+			"pageInfo": {"hasNextPage": false}}}}}`,
+	}
+
+	var cursors []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		cursor, _ := body.Variables[metrics.VarCursor].(string)
+		cursors = append(cursors, cursor)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(pages[cursor]))
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		filter      DiscoveryFilter
+		want        []string
+		wantCursors []string
+	}{
+		{
+			name:        "archived and forks left out",
+			want:        []string{"acme/api", "acme/web", "acme/cli"},
+			wantCursors: []string{"", "page2"},
+		},
+		{
+			name:        "everything",
+			filter:      DiscoveryFilter{IncludeArchived: true, IncludeForks: true},
+			want:        []string{"acme/api", "acme/old", "acme/fork", "acme/web", "acme/cli"},
+			wantCursors: []string{"", "page2"},
+		},
+		{
+			name:        "language and stars",
+			filter:      DiscoveryFilter{Languages: []string{"GO"}, MinStars: 100},
+			want:        []string{"acme/api"},
+			wantCursors: []string{"", "page2"},
+		},
+		{
+			name:        "topic",
+			filter:      DiscoveryFilter{Topics: []string{"cli", "HTTP"}},
+			want:        []string{"acme/api"},
+			wantCursors: []string{"", "page2"},
+		},
+		{
+			name:        "pushed since stops paging",
+			filter:      DiscoveryFilter{PushedSince: time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)},
+			want:        []string{"acme/api"},
+			wantCursors: []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursors = nil
+			got, err := client.ListRepositories(ctx, OwnerOrganization, "acme", tt.filter)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantCursors, cursors)
+		})
+	}
+
+	t.Run("not a user", func(t *testing.T) {
+		_, err := client.ListRepositories(ctx, OwnerUser, "acme", DiscoveryFilter{})
+		require.ErrorIs(t, err, ErrInvalidName)
+		require.ErrorContains(t, err, "acme is not a user")
+	})
+}
+
+func TestListRepositoriesUnknownOwner(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"repositoryOwner": null}}`))
+	})
+
+	_, err := client.ListRepositories(context.Background(), OwnerUser, "ghost", DiscoveryFilter{})
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,128 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+)
+
+type ErrorCode string
+
+const (
+	CodeNotFound        ErrorCode = "NOT_FOUND"
+	CodeAccessDenied    ErrorCode = "ACCESS_DENIED"
+	CodeRateLimited     ErrorCode = "RATE_LIMITED"
+	CodeInvalidName     ErrorCode = "INVALID_NAME"
+	CodeUpstreamFailure ErrorCode = "UPSTREAM_FAILURE"
+	CodeTimeout         ErrorCode = "TIMEOUT"
+	CodeCanceled        ErrorCode = "CANCELED"
+)
+
+// Error describes why a repository could not be analyzed. Use errors.As to
+// inspect the code, or errors.Is against the sentinels below.
+type Error struct {
+	Code       ErrorCode
+	Repository string
+	Err        error
+}
+
+var (
+	ErrNotFound        = &Error{Code: CodeNotFound}
+	ErrAccessDenied    = &Error{Code: CodeAccessDenied}
+	ErrRateLimited     = &Error{Code: CodeRateLimited}
+	ErrInvalidName     = &Error{Code: CodeInvalidName}
+	ErrUpstreamFailure = &Error{Code: CodeUpstreamFailure}
+	ErrTimeout         = &Error{Code: CodeTimeout}
+	ErrCanceled        = &Error{Code: CodeCanceled}
+)
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return string(e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code && t.Repository == "" && t.Err == nil
+}
+
+// ErrorCodeOf returns the code of the first Error in err's chain, classifying
+// err on the spot if it never went through the client.
+func ErrorCodeOf(err error) ErrorCode {
+	var ghErr *Error
+	if errors.As(err, &ghErr) {
+		return ghErr.Code
+	}
+	return classifyErrorCode(err)
+}
+
+// newRepositoryError classifies err, raised while fetching repo, into an Error.
+func newRepositoryError(repo string, err error) error {
+	var ghErr *Error
+	if errors.As(err, &ghErr) {
+		return err
+	}
+	return &Error{Code: classifyErrorCode(err), Repository: repo, Err: err}
+}
+
+func classifyErrorCode(err error) ErrorCode {
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		switch {
+		case statusErr.StatusCode == http.StatusNotFound:
+			return CodeNotFound
+		case statusErr.StatusCode == http.StatusTooManyRequests:
+			return CodeRateLimited
+		case statusErr.StatusCode == http.StatusForbidden:
+			if statusErr.rateLimited() {
+				return CodeRateLimited
+			}
+			return CodeAccessDenied
+		case statusErr.StatusCode == http.StatusUnauthorized:
+			return CodeAccessDenied
+		case statusErr.StatusCode == http.StatusGatewayTimeout:
+			return CodeTimeout
+		default:
+			return CodeUpstreamFailure
+		}
+	}
+
+	if errors.Is(err, ErrNoUsableToken) {
+		return CodeAccessDenied
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return CodeTimeout
+	}
+	if errors.Is(err, context.Canceled) {
+		return CodeCanceled
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return CodeTimeout
+	}
+
+	message := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(message, "could not resolve to a repository"):
+		// GitHub answers the same way for private repositories we cannot see.
+		return CodeNotFound
+	case strings.Contains(message, "rate limit"):
+		return CodeRateLimited
+	case strings.Contains(message, "resource not accessible"),
+		strings.Contains(message, "forbidden"),
+		strings.Contains(message, "saml enforcement"):
+		return CodeAccessDenied
+	case strings.Contains(message, "timeout"):
+		return CodeTimeout
+	default:
+		return CodeUpstreamFailure
+	}
+}
@@ -0,0 +1,59 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCode
+	}{
+		{name: "404", err: &HTTPStatusError{StatusCode: http.StatusNotFound}, want: CodeNotFound},
+		{name: "401", err: &HTTPStatusError{StatusCode: http.StatusUnauthorized}, want: CodeAccessDenied},
+		{name: "403", err: &HTTPStatusError{StatusCode: http.StatusForbidden}, want: CodeAccessDenied},
+		{name: "403 secondary rate limit", err: &HTTPStatusError{StatusCode: http.StatusForbidden, Body: "You have exceeded a secondary rate limit"}, want: CodeRateLimited},
+		{name: "403 primary rate limit", err: &HTTPStatusError{StatusCode: http.StatusForbidden, Body: `{"message":"API rate limit exceeded for user ID 1."}`}, want: CodeRateLimited},
+		{name: "403 rate limit exhausted", err: &HTTPStatusError{StatusCode: http.StatusForbidden, RateLimitExhausted: true}, want: CodeRateLimited},
+		{name: "429", err: &HTTPStatusError{StatusCode: http.StatusTooManyRequests}, want: CodeRateLimited},
+		{name: "504", err: &HTTPStatusError{StatusCode: http.StatusGatewayTimeout}, want: CodeTimeout},
+		{name: "500", err: &HTTPStatusError{StatusCode: http.StatusInternalServerError}, want: CodeUpstreamFailure},
+		{name: "unresolved repository", err: errors.New("Could not resolve to a Repository with the name 'a/b'."), want: CodeNotFound},
+		{name: "graphql rate limit", err: errors.New("API rate limit exceeded for user ID 1."), want: CodeRateLimited},
+		{name: "saml", err: errors.New("Resource protected by organization SAML enforcement."), want: CodeAccessDenied},
+		{name: "deadline", err: fmt.Errorf("query: %w", context.DeadlineExceeded), want: CodeTimeout},
+		{name: "canceled", err: fmt.Errorf("query: %w", context.Canceled), want: CodeCanceled},
+		{name: "no usable token", err: ErrNoUsableToken, want: CodeAccessDenied},
+		{name: "unknown", err: errors.New("boom"), want: CodeUpstreamFailure},
+		{name: "typed", err: fmt.Errorf("wrapped: %w", &Error{Code: CodeInvalidName, Err: errors.New("bad")}), want: CodeInvalidName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ErrorCodeOf(tt.err))
+		})
+	}
+}
+
+func TestErrorMatching(t *testing.T) {
+	err := fmt.Errorf("failed to collect metrics for a/b: %w",
+		newRepositoryError("a/b", &HTTPStatusError{StatusCode: http.StatusNotFound}))
+
+	require.ErrorIs(t, err, ErrNotFound)
+	require.NotErrorIs(t, err, ErrAccessDenied)
+
+	var ghErr *Error
+	require.ErrorAs(t, err, &ghErr)
+	require.Equal(t, "a/b", ghErr.Repository)
+	require.Equal(t, CodeNotFound, ghErr.Code)
+
+	var statusErr *HTTPStatusError
+	require.ErrorAs(t, err, &statusErr)
+}
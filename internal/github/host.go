@@ -0,0 +1,109 @@
+package github
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultHost    = "github.com"
+	DefaultAPIURL  = "https://api.github.com/graphql"
+	DefaultRESTURL = "https://api.github.com"
+)
+
+// HostConfig describes how to reach one GitHub instance, either github.com or a
+// GitHub Enterprise Server.
+type HostConfig struct {
+	Host     string     `mapstructure:"host"`
+	APIURL   string     `mapstructure:"api_url"`
+	RESTURL  string     `mapstructure:"rest_url"`
+	Token    string     `mapstructure:"token"`
+	Tokens   []string   `mapstructure:"tokens"`
+	CABundle string     `mapstructure:"ca_bundle"`
+	Proxy    string     `mapstructure:"proxy"`
+	App      *AppConfig `mapstructure:"app"`
+}
+
+func (hc HostConfig) hostName() string {
+	if hc.Host == "" {
+		return DefaultHost
+	}
+	return strings.ToLower(hc.Host)
+}
+
+// HasCredentials reports whether the host is configured with a token, a token
+// pool or a GitHub App.
+func (hc HostConfig) HasCredentials() bool {
+	return hc.Token != "" || len(hc.Tokens) > 0 || hc.App.Enabled()
+}
+
+func (hc HostConfig) apiURL() string {
+	switch {
+	case hc.APIURL != "":
+		return hc.APIURL
+	case hc.hostName() == DefaultHost:
+		return DefaultAPIURL
+	default:
+		return "https://" + hc.hostName() + "/api/graphql"
+	}
+}
+
+func (hc HostConfig) restURL() string {
+	switch {
+	case hc.RESTURL != "":
+		return hc.RESTURL
+	case hc.hostName() == DefaultHost:
+		return DefaultRESTURL
+	default:
+		return "https://" + hc.hostName() + "/api/v3"
+	}
+}
+
+// HTTPClient returns a client going through the host's proxy and trusting its
+// CA bundle, for requests made on the host's behalf outside the GitHub API.
+func (hc HostConfig) HTTPClient(timeout time.Duration) (*http.Client, error) {
+	transport, err := hc.transport()
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: transport, Timeout: timeout}, nil
+}
+
+func (hc HostConfig) transport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if hc.Proxy != "" {
+		proxyURL, err := url.Parse(hc.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy for %s: %w", hc.hostName(), err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if hc.CABundle != "" {
+		pem, err := os.ReadFile(hc.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle for %s: %w", hc.hostName(), err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", hc.CABundle)
+		}
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	return transport, nil
+}
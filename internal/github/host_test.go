@@ -0,0 +1,79 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdimtriCP/gh-inspector/internal/scoring"
+)
+
+func TestHostConfigAPIURL(t *testing.T) {
+	require.Equal(t, DefaultAPIURL, HostConfig{}.apiURL())
+	require.Equal(t, "https://ghe.corp.local/api/graphql", HostConfig{Host: "ghe.corp.local"}.apiURL())
+	require.Equal(t, "https://custom/graphql", HostConfig{Host: "ghe.corp.local", APIURL: "https://custom/graphql"}.apiURL())
+}
+
+func TestHostConfigRESTURL(t *testing.T) {
+	require.Equal(t, DefaultRESTURL, HostConfig{}.restURL())
+	require.Equal(t, "https://ghe.corp.local/api/v3", HostConfig{Host: "ghe.corp.local"}.restURL())
+	require.Equal(t, "https://custom/rest", HostConfig{Host: "ghe.corp.local", RESTURL: "https://custom/rest"}.restURL())
+}
+
+func TestHostConfigHasCredentials(t *testing.T) {
+	require.False(t, HostConfig{Host: "ghe.corp.local"}.HasCredentials())
+	require.False(t, HostConfig{Host: "ghe.corp.local", App: &AppConfig{}}.HasCredentials())
+	require.True(t, HostConfig{Host: "ghe.corp.local", Token: "t"}.HasCredentials())
+	require.True(t, HostConfig{Host: "ghe.corp.local", Tokens: []string{"a", "b"}}.HasCredentials())
+	require.True(t, HostConfig{Host: "ghe.corp.local", App: &AppConfig{AppID: 1}}.HasCredentials())
+}
+
+func TestNewHostClient(t *testing.T) {
+	t.Run("proxy", func(t *testing.T) {
+		client, err := NewHostClient(HostConfig{Host: "ghe.corp.local", Proxy: "http://proxy.corp.local:3128"})
+		require.NoError(t, err)
+		require.Equal(t, "ghe.corp.local", client.host)
+	})
+
+	t.Run("missing CA bundle", func(t *testing.T) {
+		_, err := NewHostClient(HostConfig{Host: "ghe.corp.local", CABundle: filepath.Join(t.TempDir(), "missing.pem")})
+		require.Error(t, err)
+	})
+
+	t.Run("CA bundle without certificates", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "empty.pem")
+		require.NoError(t, os.WriteFile(path, []byte("not a certificate"), 0600))
+
+		_, err := NewHostClient(HostConfig{Host: "ghe.corp.local", CABundle: path})
+		require.ErrorContains(t, err, "no certificates")
+	})
+}
+
+func TestRepoAnalyzerHosts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/repos/") {
+			defaultREST(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"repository": {"owner": {"login": "team"}, "name": "svc"}}}`))
+	}))
+	defer srv.Close()
+
+	analyzer := NewRepoAnalyzer("test-token", scoring.DefaultConfig())
+	require.NoError(t, analyzer.AddHost(HostConfig{Host: "ghe.corp.local", APIURL: srv.URL, RESTURL: srv.URL, Token: "ghe-token"}))
+
+	repo, err := analyzer.Analyze(context.Background(), "ghe.corp.local/team/svc")
+	require.NoError(t, err)
+	require.Equal(t, "ghe.corp.local", repo.Host)
+	require.Equal(t, "ghe.corp.local/team/svc", repo.FullName())
+
+	_, err = analyzer.Analyze(context.Background(), "unknown.example.com/team/svc")
+	require.ErrorContains(t, err, "no configuration for GitHub host unknown.example.com")
+}
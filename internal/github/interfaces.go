@@ -12,6 +12,21 @@ type MetricsCollector interface {
 	CollectBasicMetrics(ctx context.Context, repoFullName string) (*metrics.Repository, error)
 }
 
+type BatchMetricsCollector interface {
+	CollectBatchMetrics(ctx context.Context, repoFullNames []string) ([]*metrics.Repository, []error)
+}
+
 type Analyzer interface {
 	Analyze(ctx context.Context, repo string) (*metrics.Repository, error)
 }
+
+type BatchAnalyzer interface {
+	Analyzer
+	BatchSize() int
+	AnalyzeBatch(ctx context.Context, repos []string) ([]*metrics.Repository, []error)
+}
+
+// Searcher turns a GitHub search query into repositories to analyze.
+type Searcher interface {
+	SearchRepositories(ctx context.Context, query string, limit int) ([]string, error)
+}
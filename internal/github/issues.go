@@ -0,0 +1,78 @@
+package github
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+// issueStats summarizes how maintainers handled the issues opened since the
+// start of the window: median time to the first maintainer comment, median
+// time to close, and the share of issues still open without such a comment.
+func issueStats(issues []metrics.IssueNode, since time.Time) (count int, medianResponse, medianClose time.Duration, unansweredShare float64) {
+	var responses, closes []time.Duration
+	unanswered := 0
+	for _, issue := range issues {
+		opened := issue.CreatedAt.Time
+		if opened.Before(since) {
+			continue
+		}
+		count++
+
+		if response, ok := firstMaintainerResponse(issue); ok {
+			responses = append(responses, response.Sub(opened))
+		} else if issue.ClosedAt == nil {
+			unanswered++
+		}
+		if issue.ClosedAt != nil {
+			closes = append(closes, issue.ClosedAt.Sub(opened))
+		}
+	}
+	if count == 0 {
+		return 0, 0, 0, 0
+	}
+
+	return count, medianDuration(responses), medianDuration(closes), float64(unanswered) / float64(count)
+}
+
+func firstMaintainerResponse(issue metrics.IssueNode) (time.Time, bool) {
+	for _, comment := range issue.Comments.Nodes {
+		if !isMaintainer(comment.AuthorAssociation) {
+			continue
+		}
+		if issue.Author != nil && comment.Author != nil && comment.Author.Login == issue.Author.Login {
+			continue
+		}
+		return comment.CreatedAt.Time, true
+	}
+	return time.Time{}, false
+}
+
+func isMaintainer(association githubv4.CommentAuthorAssociation) bool {
+	switch association {
+	case githubv4.CommentAuthorAssociationOwner,
+		githubv4.CommentAuthorAssociationMember,
+		githubv4.CommentAuthorAssociationCollaborator:
+		return true
+	default:
+		return false
+	}
+}
+
+func medianDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
@@ -0,0 +1,62 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+func TestIssueStats(t *testing.T) {
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(days, hours int) githubv4.DateTime {
+		return githubv4.DateTime{Time: since.AddDate(0, 0, days).Add(time.Duration(hours) * time.Hour)}
+	}
+	closedAt := func(days, hours int) *githubv4.DateTime {
+		d := at(days, hours)
+		return &d
+	}
+	comment := func(login string, association githubv4.CommentAuthorAssociation, created githubv4.DateTime) metrics.IssueComment {
+		return metrics.IssueComment{Author: &metrics.Actor{Login: githubv4.String(login)}, AuthorAssociation: association, CreatedAt: created}
+	}
+	issue := func(author string, created githubv4.DateTime, closed *githubv4.DateTime, comments ...metrics.IssueComment) metrics.IssueNode {
+		node := metrics.IssueNode{Author: &metrics.Actor{Login: githubv4.String(author)}, CreatedAt: created, ClosedAt: closed}
+		node.Comments.Nodes = comments
+		return node
+	}
+
+	issues := []metrics.IssueNode{
+		// Answered by a member after 2 hours, closed after 1 day.
+		issue("user1", at(1, 0), closedAt(2, 0),
+			comment("user2", githubv4.CommentAuthorAssociationNone, at(1, 1)),
+			comment("maint", githubv4.CommentAuthorAssociationMember, at(1, 2))),
+		// Answered by the owner after 6 hours, still open.
+		issue("user3", at(3, 0), nil,
+			comment("owner", githubv4.CommentAuthorAssociationOwner, at(3, 6))),
+		// A maintainer commenting on their own issue is not a response.
+		issue("maint", at(4, 0), nil,
+			comment("maint", githubv4.CommentAuthorAssociationMember, at(4, 1))),
+		// Closed without comment after 3 days.
+		issue("user4", at(5, 0), closedAt(8, 0)),
+		// Opened before the window.
+		issue("user5", at(-10, 0), nil),
+	}
+
+	count, medianResponse, medianClose, unanswered := issueStats(issues, since)
+	require.Equal(t, 4, count)
+	require.Equal(t, 4*time.Hour, medianResponse)
+	require.Equal(t, 48*time.Hour, medianClose)
+	require.InDelta(t, 0.25, unanswered, 0.0001)
+
+	count, _, _, _ = issueStats(nil, since)
+	require.Zero(t, count)
+}
+
+func TestMedianDuration(t *testing.T) {
+	require.Zero(t, medianDuration(nil))
+	require.Equal(t, 2*time.Hour, medianDuration([]time.Duration{3 * time.Hour, time.Hour, 2 * time.Hour}))
+	require.Equal(t, 90*time.Minute, medianDuration([]time.Duration{2 * time.Hour, time.Hour}))
+}
@@ -5,44 +5,194 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/shurcooL/githubv4"
 
 	"github.com/kdimtriCP/gh-inspector/internal/cache"
+	"github.com/kdimtriCP/gh-inspector/internal/license"
 	"github.com/kdimtriCP/gh-inspector/internal/metrics"
 )
 
 func (c *Client) CollectBasicMetrics(ctx context.Context, repoFullName string) (*metrics.Repository, error) {
-	if c.cache != nil {
-		cacheKey := cache.GenerateKey("repo", repoFullName)
-		if data, found, err := c.cache.Get(cacheKey); err == nil && found {
-			var result metrics.Repository
-			if err := json.Unmarshal(data, &result); err == nil {
-				c.metricsRecorder.RecordCacheHit()
-				return &result, nil
+	ref, err := ParseReference(repoFullName)
+	if err != nil {
+		return nil, err
+	}
+
+	if result, found := c.getCached(ref); found {
+		return result, nil
+	}
+
+	return c.fetchRepository(ctx, ref)
+}
+
+func (c *Client) fetchRepository(ctx context.Context, ref Reference) (*metrics.Repository, error) {
+	var query metrics.RepositoryQuery
+	since := c.historySince()
+	variables := map[string]interface{}{
+		metrics.VarOwner: githubv4.String(ref.Owner),
+		metrics.VarName:  githubv4.String(ref.Name),
+		metrics.VarSince: since,
+	}
+
+	err := c.query(ctx, &query, variables, &query.RateLimit)
+	if err != nil {
+		return nil, newRepositoryError(ref.FullName(), fmt.Errorf("failed to fetch repository data: %w", err))
+	}
+	if err := c.completeHistory(ctx, ref, since, &query.Repository); err != nil {
+		return nil, err
+	}
+
+	result := c.buildRepository(&query.Repository, since.Time)
+	partial, err := c.collectRESTMetrics(ctx, ref, result)
+	if err != nil {
+		return nil, err
+	}
+	c.storeResult(ref, result, partial)
+
+	return result, nil
+}
+
+// storeResult caches result under the name GitHub answered with. When that
+// differs from ref, the repository was renamed or transferred: the old name
+// is kept as an alias and result is marked as moved. Partial results, whose
+// commit statistics GitHub was still computing, are not cached so the next
+// run asks for them again.
+func (c *Client) storeResult(ref Reference, result *metrics.Repository, partial bool) {
+	canonical := Reference{Host: c.host, Owner: strings.ToLower(result.Owner), Name: strings.ToLower(result.Name)}
+	if !partial {
+		c.setCached(canonical, result)
+	}
+
+	if canonical.FullName() == ref.FullName() {
+		return
+	}
+	c.setAlias(ref, canonical)
+	result.MovedFrom = Reference{Host: c.host, Owner: ref.Owner, Name: ref.Name}.String()
+}
+
+func (c *Client) getCached(ref Reference) (*metrics.Repository, bool) {
+	if c.cache == nil {
+		return nil, false
+	}
+
+	result, found := c.readCached(ref)
+	if !found {
+		if canonical, ok := c.getAlias(ref); ok {
+			if result, found = c.readCached(canonical); found {
+				result.MovedFrom = Reference{Host: c.host, Owner: ref.Owner, Name: ref.Name}.String()
 			}
 		}
+	}
+
+	if found {
+		c.metricsRecorder.RecordCacheHit()
+	} else {
 		c.metricsRecorder.RecordCacheMiss()
 	}
+	return result, found
+}
 
-	parts := strings.Split(repoFullName, "/")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("invalid repository format, expected owner/name")
+func (c *Client) readCached(ref Reference) (*metrics.Repository, bool) {
+	cacheKey := cache.GenerateKey("repo", c.host, ref.FullName())
+	if data, found, err := c.cache.Get(cacheKey); err == nil && found {
+		var result metrics.Repository
+		if err := json.Unmarshal(data, &result); err == nil {
+			return &result, true
+		}
 	}
-	owner, name := parts[0], parts[1]
+	return nil, false
+}
 
-	var query metrics.RepositoryQuery
-	variables := map[string]interface{}{
-		metrics.VarOwner: githubv4.String(owner),
-		metrics.VarName:  githubv4.String(name),
+func (c *Client) setCached(ref Reference, result *metrics.Repository) {
+	if c.cache == nil {
+		return
+	}
+
+	cacheKey := cache.GenerateKey("repo", c.host, ref.FullName())
+	if data, err := json.Marshal(result); err == nil {
+		_ = c.cache.Set(cacheKey, data, c.cacheTTL)
+	}
+}
+
+func (c *Client) getAlias(ref Reference) (Reference, bool) {
+	cacheKey := cache.GenerateKey("alias", c.host, ref.FullName())
+	data, found, err := c.cache.Get(cacheKey)
+	if err != nil || !found {
+		return Reference{}, false
 	}
+	canonical, err := ParseReference(string(data))
+	if err != nil {
+		return Reference{}, false
+	}
+	return canonical, true
+}
+
+func (c *Client) setAlias(ref, canonical Reference) {
+	if c.cache == nil {
+		return
+	}
+
+	cacheKey := cache.GenerateKey("alias", c.host, ref.FullName())
+	_ = c.cache.Set(cacheKey, []byte(canonical.FullName()), c.cacheTTL)
+}
+
+func (c *Client) buildRepository(repo *metrics.RepositoryGraphQL, since time.Time) *metrics.Repository {
+	result := buildRepository(repo, since)
+	if c.host != DefaultHost {
+		result.Host = c.host
+	}
+	return result
+}
 
-	err := c.graphqlClient.Query(ctx, &query, variables)
+// collectRESTMetrics adds the metrics only the REST API provides to result.
+// GitHub computes commit statistics on first request, so they are asked for
+// first and picked up after the security settings, backing off between
+// attempts as for retries. If they are still not ready, the repository is
+// reported without them and partial is set. At most as many repositories as
+// the client's concurrency are collected at once.
+func (c *Client) collectRESTMetrics(ctx context.Context, ref Reference, result *metrics.Repository) (partial bool, err error) {
+	slots := c.restSlots
+	select {
+	case slots <- struct{}{}:
+		defer func() { <-slots }()
+	case <-ctx.Done():
+		return false, newRepositoryError(ref.FullName(), ctx.Err())
+	}
+
+	weekly, pending, err := c.weeklyCommits(ctx, ref)
+	if err != nil {
+		return false, err
+	}
+
+	security, err := c.securityPosture(ctx, ref, result.DefaultBranch, result.ViewerCanAdminister)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch repository data: %w", err)
+		return false, err
+	}
+
+	for attempt := 1; pending && attempt < max(c.retryConfig.MaxAttempts, 2); attempt++ {
+		if attempt > 1 {
+			if err := sleepContext(ctx, c.retryConfig.backoff(attempt-1)); err != nil {
+				return false, err
+			}
+		}
+		if weekly, pending, err = c.weeklyCommits(ctx, ref); err != nil {
+			return false, err
+		}
 	}
+	result.WeeklyCommits = weekly
+	result.BranchProtected = security.branchProtected
+	result.RequiredReviews = security.requiredReviews
+	result.RequiresStatusChecks = security.requiresStatusChecks
+	result.VulnerabilityAlerts = security.vulnerabilityAlerts
+	result.SecurityAdvisories = security.advisories
+	return pending, nil
+}
 
-	repo := query.Repository
+// buildRepository maps the query result onto metrics.Repository. Activity
+// metrics only consider what happened after since.
+func buildRepository(repo *metrics.RepositoryGraphQL, since time.Time) *metrics.Repository {
 	result := &metrics.Repository{
 		Owner:       string(repo.Owner.Login),
 		Name:        string(repo.Name),
@@ -52,52 +202,81 @@ func (c *Client) CollectBasicMetrics(ctx context.Context, repoFullName string) (
 		OpenPRs:     int(repo.PullRequests.TotalCount),
 		Description: string(repo.Description),
 		IsArchived:  bool(repo.IsArchived),
+		IsFork:      bool(repo.IsFork),
+		IsTemplate:  bool(repo.IsTemplate),
+		HomepageURL: string(repo.HomepageURL),
+		CreatedAt:   repo.CreatedAt.Time,
+		DiskUsageKB: int(repo.DiskUsage),
 		HasLicense:  repo.LicenseInfo != nil,
 		Watchers:    int(repo.Watchers.TotalCount),
+
+		ViewerCanAdminister: repo.ViewerPermission == githubv4.RepositoryPermissionAdmin,
+	}
+
+	if repo.LicenseInfo != nil {
+		result.LicenseSPDX = string(repo.LicenseInfo.SpdxID)
+		if result.LicenseSPDX == "" {
+			result.LicenseSPDX = license.NoAssertion
+		}
 	}
+	result.LicenseClass = string(license.Classify(result.LicenseSPDX))
 
 	if repo.PrimaryLanguage != nil {
 		result.PrimaryLanguage = string(repo.PrimaryLanguage.Name)
 	}
+	for _, edge := range repo.Languages.Edges {
+		result.Languages = append(result.Languages, metrics.LanguageSize{Name: string(edge.Node.Name), Bytes: int(edge.Size)})
+	}
+	for _, node := range repo.RepositoryTopics.Nodes {
+		result.Topics = append(result.Topics, string(node.Topic.Name))
+	}
+	if repo.Parent != nil {
+		result.Parent = string(repo.Parent.NameWithOwner)
+	}
 
 	if repo.DefaultBranchRef != nil && len(repo.DefaultBranchRef.Target.Commit.History.Edges) > 0 {
 		result.LastCommitDate = repo.DefaultBranchRef.Target.Commit.History.Edges[0].Node.CommittedDate.Time
 	}
 
-	for _, entry := range repo.Object.Tree.Entries {
-		entryLower := strings.ToLower(string(entry.Name))
-		if strings.HasPrefix(entryLower, metrics.CIGitHub) ||
-			strings.HasPrefix(entryLower, metrics.CIGitLab) ||
-			strings.HasPrefix(entryLower, metrics.CICircleCI) ||
-			entryLower == metrics.CITravis ||
-			entryLower == metrics.CIJenkins {
-			result.HasCICD = true
-		}
-		if strings.HasPrefix(entryLower, metrics.FileContributingAlt) {
-			result.HasContributing = true
-		}
-		if strings.HasPrefix(entryLower, "readme") {
-			result.HasReadme = true
-		}
-		if strings.HasPrefix(entryLower, "code_of_conduct") || strings.HasPrefix(entryLower, "code-of-conduct") {
-			result.HasCodeOfConduct = true
-		}
-		if strings.HasPrefix(entryLower, "security") {
-			result.HasSecurity = true
-		}
+	if repo.DefaultBranchRef != nil {
+		result.DefaultBranch = string(repo.DefaultBranchRef.Name)
+		recent := repo.DefaultBranchRef.Target.Commit.Recent
+		result.CommitCount = int(recent.TotalCount)
+		result.ContributorCount, result.TopContributorShare, result.BusFactor = contributorStats(recent.Nodes)
 	}
 
+	ci := ciStats(repo.Object.Tree.Entries, repo.GitHubDir.Tree.Entries, repo.Workflows.Tree.Entries)
+	result.HasCICD = len(ci.providers) > 0
+	result.CIProviders = ci.providers
+	result.TestWorkflows = ci.testWorkflows
+	result.ReleaseWorkflows = ci.releaseWorkflows
+	result.BotWorkflows = ci.botWorkflows
+	result.HasDependabot = ci.dependabot
+	result.HasRenovate = ci.renovate
+	result.HasCodeQL = ci.codeQL
+
+	result.CommunityFiles = communityFiles(repo.Object.Tree.Entries, repo.GitHubDir.Tree.Entries, repo.DocsDir.Tree.Entries)
+	result.HasReadme = result.CommunityFiles[metrics.CommunityReadme] != ""
+	result.HasContributing = result.CommunityFiles[metrics.CommunityContributing] != ""
+	result.HasCodeOfConduct = result.CommunityFiles[metrics.CommunityCodeOfConduct] != ""
+	result.HasSecurity = result.CommunityFiles[metrics.CommunitySecurity] != ""
+
+	result.RecentIssueCount, result.MedianIssueResponse, result.MedianIssueCloseTime, result.UnansweredIssueShare =
+		issueStats(repo.RecentIssues.Nodes, since)
+	result.RecentMergedPRs, result.RecentClosedPRs, result.MedianPRMergeTime, result.ApprovedMergeShare =
+		pullRequestStats(repo.RecentPullRequests.Nodes, since)
+	result.StalePRCount = stalePullRequests(repo.IdlePullRequests.Nodes, time.Now().Add(-stalePullRequestAge))
+
 	result.ReleaseCount = int(repo.Releases.TotalCount)
 	if len(repo.Releases.Edges) > 0 {
 		result.LastReleaseDate = repo.Releases.Edges[0].Node.PublishedAt.Time
 	}
+	releases := releaseStats(repo.Releases.Edges, time.Now())
+	result.MedianReleaseInterval = releases.medianInterval
+	result.SemverReleaseShare = releases.semverShare
+	result.PrereleaseShare = releases.prereleaseShare
+	result.ReleaseNotesShare = releases.notesShare
+	result.RecentMajorBumps = releases.majorBumps
 
-	if c.cache != nil {
-		cacheKey := cache.GenerateKey("repo", repoFullName)
-		if data, err := json.Marshal(result); err == nil {
-			_ = c.cache.Set(cacheKey, data, c.cacheTTL)
-		}
-	}
-
-	return result, nil
+	return result
 }
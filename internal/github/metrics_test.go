@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdimtriCP/gh-inspector/internal/cache"
+	"github.com/kdimtriCP/gh-inspector/internal/license"
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+	"github.com/kdimtriCP/gh-inspector/internal/scoring"
+)
+
+func TestCollectBasicMetricsMovedRepository(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"repository": {"owner": {"login": "new-org"}, "name": "Renamed"}}}`))
+	})
+
+	c, err := cache.New(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	client.SetCache(c)
+
+	repo, err := client.CollectBasicMetrics(context.Background(), "old-org/repo")
+	require.NoError(t, err)
+	require.Equal(t, "new-org/Renamed", repo.FullName())
+	require.Equal(t, "old-org/repo", repo.MovedFrom)
+
+	t.Run("old name served through alias", func(t *testing.T) {
+		repo, err := client.CollectBasicMetrics(context.Background(), "Old-Org/Repo")
+		require.NoError(t, err)
+		require.Equal(t, "new-org/Renamed", repo.FullName())
+		require.Equal(t, "old-org/repo", repo.MovedFrom)
+		require.Equal(t, 1, requests)
+	})
+
+	t.Run("new name cached without move", func(t *testing.T) {
+		repo, err := client.CollectBasicMetrics(context.Background(), "new-org/renamed")
+		require.NoError(t, err)
+		require.Empty(t, repo.MovedFrom)
+		require.Equal(t, 1, requests)
+	})
+}
+
+func TestRepoAnalyzerLicensePolicy(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"repository": {
+			"owner": {"login": "test"}, "name": "repo", "stargazerCount": 100000,
+			"licenseInfo": {"key": "agpl-3.0", "spdxId": "AGPL-3.0"}
+		}}}`))
+	})
+
+	analyzer := NewRepoAnalyzer("test-token", scoring.DefaultConfig())
+	analyzer.client = client
+	analyzer.clients[DefaultHost] = client
+
+	repo, err := analyzer.Analyze(context.Background(), "test/repo")
+	require.NoError(t, err)
+	require.Equal(t, "AGPL-3.0", repo.LicenseSPDX)
+	require.Equal(t, string(license.ClassStrongCopyleft), repo.LicenseClass)
+	require.Empty(t, repo.LicenseViolation)
+	require.Greater(t, repo.Score, 20.0)
+
+	analyzer.SetLicensePolicy(&license.Policy{Deny: []string{"strong-copyleft"}, Action: license.ActionCap, MaxScore: 20})
+	repo, err = analyzer.Analyze(context.Background(), "test/repo")
+	require.NoError(t, err)
+	require.Equal(t, "license AGPL-3.0 (strong-copyleft) is denied", repo.LicenseViolation)
+	require.Equal(t, 20.0, repo.Score)
+}
+
+func TestCollectBasicMetricsCI(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"repository": {
+			"owner": {"login": "test"}, "name": "repo",
+			"object": {"entries": [{"name": ".github"}, {"name": "Jenkinsfile"}]},
+			"githubDir": {"entries": [{"name": "ISSUE_TEMPLATE"}, {"name": "dependabot.yml"}]},
+			"workflows": {"entries": [{"name": "stale.yml", "object": {"text": "on: schedule\njobs:\n  stale:\n    steps:\n      - uses: actions/stale@v9\n"}}]}
+		}}}`))
+	})
+
+	repo, err := client.CollectBasicMetrics(context.Background(), "test/repo")
+	require.NoError(t, err)
+	require.True(t, repo.HasCICD)
+	require.Equal(t, []string{"Jenkins"}, repo.CIProviders)
+	require.Equal(t, 0, repo.TestWorkflows)
+	require.Equal(t, 1, repo.BotWorkflows)
+	require.True(t, repo.HasDependabot)
+
+	t.Run("without workflows", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data": {"repository": {
+				"owner": {"login": "test"}, "name": "repo",
+				"object": {"entries": [{"name": ".github"}]},
+				"githubDir": {"entries": [{"name": "ISSUE_TEMPLATE"}]},
+				"workflows": null
+			}}}`))
+		})
+
+		repo, err := client.CollectBasicMetrics(context.Background(), "test/repo")
+		require.NoError(t, err)
+		require.False(t, repo.HasCICD)
+		require.Empty(t, repo.CIProviders)
+	})
+}
+
+func TestCollectBasicMetricsMetadata(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"repository": {
+			"owner": {"login": "test"}, "name": "repo",
+			"isFork": true, "parent": {"nameWithOwner": "upstream/repo"}, "isTemplate": false,
+			"homepageUrl": "https://example.com", "createdAt": "2020-01-02T03:04:05Z", "diskUsage": 2048,
+			"defaultBranchRef": {"name": "main"},
+			"repositoryTopics": {"nodes": [{"topic": {"name": "cli"}}, {"topic": {"name": "github"}}]},
+			"languages": {"totalSize": 3000, "edges": [{"size": 2500, "node": {"name": "Go"}}, {"size": 500, "node": {"name": "Shell"}}]}
+		}}}`))
+	})
+
+	repo, err := client.CollectBasicMetrics(context.Background(), "test/repo")
+	require.NoError(t, err)
+	require.True(t, repo.IsFork)
+	require.Equal(t, "upstream/repo", repo.Parent)
+	require.Equal(t, "https://example.com", repo.HomepageURL)
+	require.Equal(t, 2020, repo.CreatedAt.Year())
+	require.Equal(t, 2048, repo.DiskUsageKB)
+	require.Equal(t, "main", repo.DefaultBranch)
+	require.Equal(t, []string{"cli", "github"}, repo.Topics)
+	require.Equal(t, []metrics.LanguageSize{{Name: "Go", Bytes: 2500}, {Name: "Shell", Bytes: 500}}, repo.Languages)
+}
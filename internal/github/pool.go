@@ -0,0 +1,108 @@
+package github
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+const DefaultConcurrency = 4
+
+type AnalysisResult struct {
+	Repository string
+	Metrics    *metrics.Repository
+	Err        error
+	Duration   time.Duration
+}
+
+// AnalyzeAll runs the analyzer over repos with at most concurrency workers.
+// Analyzers that support batching receive the repositories in batches, each
+// batch being one unit of work. Results keep the input order. Repositories not
+// yet started when ctx is cancelled are reported with the context error.
+func AnalyzeAll(ctx context.Context, analyzer Analyzer, repos []string, concurrency int) []AnalysisResult {
+	results := make([]AnalysisResult, len(repos))
+
+	if batcher, ok := analyzer.(BatchAnalyzer); ok && len(repos) > 1 {
+		size := max(batcher.BatchSize(), 1)
+		batches := chunk(repos, size)
+
+		RunPool(len(batches), concurrency, func(b int) {
+			analyzeBatch(ctx, batcher, batches[b], results[b*size:])
+		})
+		return results
+	}
+
+	RunPool(len(repos), concurrency, func(i int) {
+		results[i] = analyzeOne(ctx, analyzer, repos[i])
+	})
+	return results
+}
+
+// RunPool calls fn for every index below n on at most concurrency goroutines,
+// DefaultConcurrency when it is not positive, and returns once all calls did.
+func RunPool(n, concurrency int, fn func(i int)) {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	if concurrency > n {
+		concurrency = n
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+func analyzeOne(ctx context.Context, analyzer Analyzer, repo string) AnalysisResult {
+	if err := ctx.Err(); err != nil {
+		return AnalysisResult{Repository: repo, Err: err}
+	}
+
+	start := time.Now()
+	m, err := analyzer.Analyze(ctx, repo)
+
+	return AnalysisResult{
+		Repository: repo,
+		Metrics:    m,
+		Err:        err,
+		Duration:   time.Since(start),
+	}
+}
+
+func analyzeBatch(ctx context.Context, batcher BatchAnalyzer, repos []string, results []AnalysisResult) {
+	if err := ctx.Err(); err != nil {
+		for i, repo := range repos {
+			results[i] = AnalysisResult{Repository: repo, Err: err}
+		}
+		return
+	}
+
+	start := time.Now()
+	ms, errs := batcher.AnalyzeBatch(ctx, repos)
+	duration := time.Since(start)
+
+	for i, repo := range repos {
+		results[i] = AnalysisResult{
+			Repository: repo,
+			Metrics:    ms[i],
+			Err:        errs[i],
+			Duration:   duration,
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+	"github.com/kdimtriCP/gh-inspector/internal/mock/mock_github"
+)
+
+func TestAnalyzeAll(t *testing.T) {
+	t.Run("keeps input order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAnalyzer := mock_github.NewMockAnalyzer(ctrl)
+		repos := []string{"test/slow", "test/fast", "test/broken"}
+
+		mockAnalyzer.EXPECT().Analyze(gomock.Any(), "test/slow").DoAndReturn(
+			func(_ context.Context, _ string) (*metrics.Repository, error) {
+				time.Sleep(50 * time.Millisecond)
+				return &metrics.Repository{Owner: "test", Name: "slow"}, nil
+			})
+		mockAnalyzer.EXPECT().Analyze(gomock.Any(), "test/fast").
+			Return(&metrics.Repository{Owner: "test", Name: "fast"}, nil)
+		mockAnalyzer.EXPECT().Analyze(gomock.Any(), "test/broken").
+			Return(nil, errors.New("boom"))
+
+		results := AnalyzeAll(context.Background(), mockAnalyzer, repos, 3)
+
+		require.Len(t, results, 3)
+		require.Equal(t, "test/slow", results[0].Repository)
+		require.Equal(t, "slow", results[0].Metrics.Name)
+		require.Equal(t, "test/fast", results[1].Repository)
+		require.Equal(t, "fast", results[1].Metrics.Name)
+		require.Equal(t, "test/broken", results[2].Repository)
+		require.Error(t, results[2].Err)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAnalyzer := mock_github.NewMockAnalyzer(ctrl)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		results := AnalyzeAll(ctx, mockAnalyzer, []string{"test/a", "test/b"}, 1)
+
+		require.Len(t, results, 2)
+		for _, result := range results {
+			require.ErrorIs(t, result.Err, context.Canceled)
+			require.Nil(t, result.Metrics)
+		}
+	})
+
+	t.Run("batch analyzer", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAnalyzer := mock_github.NewMockBatchAnalyzer(ctrl)
+		mockAnalyzer.EXPECT().BatchSize().Return(2).AnyTimes()
+		mockAnalyzer.EXPECT().AnalyzeBatch(gomock.Any(), []string{"test/a", "test/b"}).
+			Return([]*metrics.Repository{{Name: "a"}, nil}, []error{nil, errors.New("not found")})
+		mockAnalyzer.EXPECT().AnalyzeBatch(gomock.Any(), []string{"test/c"}).
+			Return([]*metrics.Repository{{Name: "c"}}, []error{nil})
+
+		results := AnalyzeAll(context.Background(), mockAnalyzer, []string{"test/a", "test/b", "test/c"}, 2)
+
+		require.Len(t, results, 3)
+		require.Equal(t, "a", results[0].Metrics.Name)
+		require.Equal(t, "test/b", results[1].Repository)
+		require.Error(t, results[1].Err)
+		require.Equal(t, "c", results[2].Metrics.Name)
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		results := AnalyzeAll(context.Background(), nil, nil, 4)
+		require.Empty(t, results)
+	})
+}
@@ -0,0 +1,49 @@
+package github
+
+import (
+	"time"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+// Open pull requests without updates for this long count as stale.
+const stalePullRequestAge = 30 * 24 * time.Hour
+
+// pullRequestStats summarizes the pull requests closed since the start of the
+// window: how many were merged or closed unmerged, the median time from
+// opening to merge, and the share of merged ones with an approving review.
+func pullRequestStats(prs []metrics.PullRequestNode, since time.Time) (merged, closed int, medianMerge time.Duration, approvedShare float64) {
+	var leadTimes []time.Duration
+	approved := 0
+	for _, pr := range prs {
+		if pr.ClosedAt == nil || pr.ClosedAt.Before(since) {
+			continue
+		}
+		if !pr.Merged || pr.MergedAt == nil {
+			closed++
+			continue
+		}
+
+		merged++
+		leadTimes = append(leadTimes, pr.MergedAt.Sub(pr.CreatedAt.Time))
+		if pr.Reviews.TotalCount > 0 {
+			approved++
+		}
+	}
+	if merged == 0 {
+		return 0, closed, 0, 0
+	}
+
+	return merged, closed, medianDuration(leadTimes), float64(approved) / float64(merged)
+}
+
+// stalePullRequests counts open pull requests last updated before cutoff.
+func stalePullRequests(prs []metrics.OpenPullRequestNode, cutoff time.Time) int {
+	stale := 0
+	for _, pr := range prs {
+		if pr.UpdatedAt.Before(cutoff) {
+			stale++
+		}
+	}
+	return stale
+}
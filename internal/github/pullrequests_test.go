@@ -0,0 +1,57 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+func TestPullRequestStats(t *testing.T) {
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(days, hours int) *githubv4.DateTime {
+		return &githubv4.DateTime{Time: since.AddDate(0, 0, days).Add(time.Duration(hours) * time.Hour)}
+	}
+	pr := func(created, closed *githubv4.DateTime, merged bool, approvals int) metrics.PullRequestNode {
+		node := metrics.PullRequestNode{CreatedAt: *created, ClosedAt: closed, Merged: githubv4.Boolean(merged)}
+		if merged {
+			node.MergedAt = closed
+		}
+		node.Reviews.TotalCount = githubv4.Int(approvals)
+		return node
+	}
+
+	prs := []metrics.PullRequestNode{
+		pr(at(1, 0), at(1, 4), true, 1),
+		pr(at(2, 0), at(4, 0), true, 0),
+		pr(at(3, 0), at(5, 0), true, 2),
+		pr(at(4, 0), at(6, 0), false, 0),
+		// Closed before the window.
+		pr(at(-20, 0), at(-10, 0), true, 1),
+	}
+
+	merged, closed, medianMerge, approvedShare := pullRequestStats(prs, since)
+	require.Equal(t, 3, merged)
+	require.Equal(t, 1, closed)
+	require.Equal(t, 48*time.Hour, medianMerge)
+	require.InDelta(t, 2.0/3.0, approvedShare, 0.0001)
+
+	merged, closed, _, _ = pullRequestStats([]metrics.PullRequestNode{pr(at(1, 0), at(2, 0), false, 0)}, since)
+	require.Zero(t, merged)
+	require.Equal(t, 1, closed)
+}
+
+func TestStalePullRequests(t *testing.T) {
+	cutoff := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	prs := []metrics.OpenPullRequestNode{
+		{UpdatedAt: githubv4.DateTime{Time: cutoff.AddDate(0, -3, 0)}},
+		{UpdatedAt: githubv4.DateTime{Time: cutoff.AddDate(0, 0, -1)}},
+		{UpdatedAt: githubv4.DateTime{Time: cutoff.AddDate(0, 0, 1)}},
+	}
+
+	require.Equal(t, 2, stalePullRequests(prs, cutoff))
+	require.Zero(t, stalePullRequests(nil, cutoff))
+}
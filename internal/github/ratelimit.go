@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+const (
+	// DefaultRateLimitReserve is the number of points kept in reserve; once the
+	// remaining budget drops to it, queries pause until the window resets.
+	DefaultRateLimitReserve = 50
+	// Below this fraction of the hourly limit, queries are spread evenly over
+	// the time left until the reset.
+	rateLimitSlowdownRatio = 0.1
+)
+
+// Rate-limit budgets, named as in the X-RateLimit-Resource header.
+const (
+	resourceGraphQL = "graphql"
+	resourceCore    = "core"
+)
+
+// requestResource names the budget req draws from: GraphQL queries are POSTs,
+// while the REST calls made here are all GETs.
+func requestResource(req *http.Request) string {
+	if req.Method == http.MethodPost {
+		return resourceGraphQL
+	}
+	return resourceCore
+}
+
+// headerRateLimit reads the X-RateLimit-* headers of a response.
+func headerRateLimit(header http.Header) (metrics.RateLimit, bool) {
+	remaining, errRemaining := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	reset, errReset := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if errRemaining != nil || errReset != nil {
+		return metrics.RateLimit{}, false
+	}
+	limit, _ := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	return metrics.RateLimit{
+		Limit:     githubv4.Int(limit),
+		Remaining: githubv4.Int(remaining),
+		ResetAt:   githubv4.DateTime{Time: time.Unix(reset, 0)},
+	}, true
+}
+
+type rateLimiter struct {
+	mu        sync.Mutex
+	reserve   int
+	limit     int
+	remaining int
+	resetAt   time.Time
+	now       func() time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		reserve: DefaultRateLimitReserve,
+		now:     time.Now,
+	}
+}
+
+func (rl *rateLimiter) setReserve(reserve int) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.reserve = reserve
+}
+
+func (rl *rateLimiter) update(state metrics.RateLimit) {
+	if state.ResetAt.IsZero() {
+		return
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.limit = int(state.Limit)
+	rl.remaining = int(state.Remaining)
+	rl.resetAt = state.ResetAt.Time
+}
+
+// delay returns how long to hold off the next query given the last known budget.
+func (rl *rateLimiter) delay() time.Duration {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.now()
+	if rl.resetAt.IsZero() || !now.Before(rl.resetAt) {
+		return 0
+	}
+
+	untilReset := rl.resetAt.Sub(now)
+	switch {
+	case rl.remaining <= rl.reserve:
+		return untilReset
+	case float64(rl.remaining) < float64(rl.limit)*rateLimitSlowdownRatio:
+		return untilReset / time.Duration(rl.remaining-rl.reserve)
+	default:
+		return 0
+	}
+}
+
+func (rl *rateLimiter) wait(ctx context.Context) (time.Duration, error) {
+	d := rl.delay()
+	if err := sleepContext(ctx, d); err != nil {
+		return 0, err
+	}
+	return d, nil
+}
+
+// waitForBudget holds off until the token that will serve the next request
+// has budget to spare on resource. Pooled tokens track their own budgets.
+func (c *Client) waitForBudget(ctx context.Context, resource string) (time.Duration, error) {
+	limiter := c.rateLimiter
+	if resource == resourceCore {
+		limiter = c.restLimiter
+	}
+	if c.tokenPool == nil {
+		return limiter.wait(ctx)
+	}
+
+	limiter.mu.Lock()
+	reserve := limiter.reserve
+	limiter.mu.Unlock()
+
+	d := c.tokenPool.delay(resource, reserve)
+	if err := sleepContext(ctx, d); err != nil {
+		return 0, err
+	}
+	return d, nil
+}
+
+// restBudgetTransport feeds the core rate-limit headers of every response,
+// failed ones included, into limiter. GraphQL queries report their budget in
+// the response body instead.
+type restBudgetTransport struct {
+	base    http.RoundTripper
+	limiter *rateLimiter
+}
+
+func (t *restBudgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.Header.Get("X-RateLimit-Resource") != resourceCore {
+		return resp, err
+	}
+	if state, ok := headerRateLimit(resp.Header); ok {
+		t.limiter.update(state)
+	}
+	return resp, nil
+}
+
+func (c *Client) SetRateLimitReserve(reserve int) {
+	c.rateLimiter.setReserve(reserve)
+	c.restLimiter.setReserve(reserve)
+}
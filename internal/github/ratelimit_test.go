@@ -0,0 +1,86 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+func TestRateLimiterDelay(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		remaining int
+		resetAt   time.Time
+		want      time.Duration
+	}{
+		{name: "unknown budget", want: 0},
+		{name: "plenty remaining", remaining: 4000, resetAt: now.Add(30 * time.Minute), want: 0},
+		{name: "reserve reached", remaining: 50, resetAt: now.Add(30 * time.Minute), want: 30 * time.Minute},
+		{name: "slow down", remaining: 150, resetAt: now.Add(100 * time.Minute), want: time.Minute},
+		{name: "window already reset", remaining: 0, resetAt: now.Add(-time.Minute), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := newRateLimiter()
+			rl.now = func() time.Time { return now }
+			rl.update(metrics.RateLimit{
+				Limit:     5000,
+				Remaining: githubv4.Int(tt.remaining),
+				ResetAt:   githubv4.DateTime{Time: tt.resetAt},
+			})
+
+			require.Equal(t, tt.want, rl.delay())
+		})
+	}
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	rl := newRateLimiter()
+	rl.update(metrics.RateLimit{
+		Limit:     5000,
+		Remaining: 0,
+		ResetAt:   githubv4.DateTime{Time: time.Now().Add(time.Hour)},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := rl.wait(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRESTCallsWaitForCoreBudget(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-RateLimit-Resource", resourceCore)
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "10")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	client := newClient(HostConfig{APIURL: srv.URL, RESTURL: srv.URL}, srv.Client().Transport)
+
+	var v []struct{}
+	require.NoError(t, client.restGet(context.Background(), "/repos/test/repo/security-advisories", &v))
+	require.Zero(t, client.rateLimiter.delay(), "REST calls should not touch the GraphQL budget")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := client.restGet(ctx, "/repos/test/repo/security-advisories", &v)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, 1, calls, "the call should wait for the core budget to reset")
+}
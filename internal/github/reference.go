@@ -0,0 +1,123 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Reference identifies a repository on a GitHub instance. Parsed references
+// are canonical: lower case and without a .git suffix, since GitHub treats
+// owner and repository names case-insensitively.
+type Reference struct {
+	Host  string
+	Owner string
+	Name  string
+}
+
+var (
+	// git@github.com:owner/name.git
+	scpLikeReference = regexp.MustCompile(`^[A-Za-z0-9._-]+@([^:/]+):(.*)$`)
+	ownerPattern     = regexp.MustCompile(`^[a-z0-9_][a-z0-9_-]*$`)
+	namePattern      = regexp.MustCompile(`^[a-z0-9._-]{1,100}$`)
+)
+
+// ParseReference accepts owner/name, host/owner/name, http(s), ssh and
+// git@host:owner/name references, as well as links to pages inside a
+// repository such as github.com/owner/name/tree/main.
+func ParseReference(input string) (Reference, error) {
+	ref, err := parseReference(strings.TrimSpace(input))
+	if err != nil {
+		return Reference{}, &Error{
+			Code:       CodeInvalidName,
+			Repository: input,
+			Err:        fmt.Errorf("invalid repository reference %q: %w", input, err),
+		}
+	}
+	return ref, nil
+}
+
+func parseReference(input string) (Reference, error) {
+	if input == "" {
+		return Reference{}, errors.New("reference is empty")
+	}
+
+	host, path, isURL := DefaultHost, input, false
+	if m := scpLikeReference.FindStringSubmatch(input); m != nil && !strings.Contains(input, "://") {
+		host, path, isURL = m[1], m[2], true
+	} else if first, _, _ := strings.Cut(input, "/"); strings.Contains(input, "://") || strings.Contains(first, ".") {
+		// GitHub logins cannot contain dots, so a dotted first segment is a host.
+		raw := input
+		if !strings.Contains(raw, "://") {
+			raw = "https://" + raw
+		}
+		u, err := url.Parse(raw)
+		if err != nil {
+			return Reference{}, fmt.Errorf("not a valid URL: %w", err)
+		}
+		if u.Hostname() == "" {
+			return Reference{}, errors.New("URL has no host")
+		}
+		host, path, isURL = u.Hostname(), u.Path, true
+	}
+
+	host = strings.ToLower(host)
+	if host == "www."+DefaultHost {
+		host = DefaultHost
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 2 || segments[0] == "" || segments[1] == "" {
+		return Reference{}, errors.New("expected owner/name, a repository URL or git@host:owner/name")
+	}
+	if len(segments) > 2 && !isURL {
+		return Reference{}, fmt.Errorf("unexpected %q after owner/name", strings.Join(segments[2:], "/"))
+	}
+
+	owner := strings.ToLower(segments[0])
+	name := strings.ToLower(strings.TrimSuffix(segments[1], ".git"))
+	if !ownerPattern.MatchString(owner) {
+		return Reference{}, fmt.Errorf("owner %q may only contain letters, digits, hyphens and underscores", segments[0])
+	}
+	if !namePattern.MatchString(name) || name == "." || name == ".." {
+		return Reference{}, fmt.Errorf("name %q must be 1-100 letters, digits, '.', '-' or '_'", segments[1])
+	}
+
+	return Reference{Host: host, Owner: owner, Name: name}, nil
+}
+
+// FullName returns owner/name.
+func (r Reference) FullName() string {
+	return r.Owner + "/" + r.Name
+}
+
+// String returns the canonical form of the reference, which carries the host
+// only for instances other than github.com.
+func (r Reference) String() string {
+	if r.Host == "" || r.Host == DefaultHost {
+		return r.FullName()
+	}
+	return r.Host + "/" + r.FullName()
+}
+
+// NormalizeRepositories rewrites repos to canonical form and drops duplicates,
+// keeping the first occurrence. Entries that do not parse are kept unchanged
+// so that analyzing them reports why.
+func NormalizeRepositories(repos []string) []string {
+	seen := make(map[string]bool, len(repos))
+	normalized := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		key := repo
+		if ref, err := ParseReference(repo); err == nil {
+			key = ref.String()
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, key)
+	}
+	return normalized
+}
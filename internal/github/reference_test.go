@@ -0,0 +1,80 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Reference
+	}{
+		{input: "golang/go", want: Reference{Host: DefaultHost, Owner: "golang", Name: "go"}},
+		{input: "  Kubernetes/Kubernetes ", want: Reference{Host: DefaultHost, Owner: "kubernetes", Name: "kubernetes"}},
+		{input: "https://github.com/golang/go", want: Reference{Host: DefaultHost, Owner: "golang", Name: "go"}},
+		{input: "https://www.github.com/golang/go/", want: Reference{Host: DefaultHost, Owner: "golang", Name: "go"}},
+		{input: "http://github.com/golang/go.git", want: Reference{Host: DefaultHost, Owner: "golang", Name: "go"}},
+		{input: "git@github.com:golang/go.git", want: Reference{Host: DefaultHost, Owner: "golang", Name: "go"}},
+		{input: "ssh://git@github.com:22/golang/go.git", want: Reference{Host: DefaultHost, Owner: "golang", Name: "go"}},
+		{input: "github.com/golang/go/tree/master/src", want: Reference{Host: DefaultHost, Owner: "golang", Name: "go"}},
+		{input: "https://github.com/golang/go/issues?q=is%3Aopen#top", want: Reference{Host: DefaultHost, Owner: "golang", Name: "go"}},
+		{input: "ghe.corp.local/team/svc", want: Reference{Host: "ghe.corp.local", Owner: "team", Name: "svc"}},
+		{input: "GHE.Corp.Local/Team/Svc", want: Reference{Host: "ghe.corp.local", Owner: "team", Name: "svc"}},
+		{input: "git@ghe.corp.local:team/svc.git", want: Reference{Host: "ghe.corp.local", Owner: "team", Name: "svc"}},
+		{input: "octo_org/my.repo-name", want: Reference{Host: DefaultHost, Owner: "octo_org", Name: "my.repo-name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			ref, err := ParseReference(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, ref)
+		})
+	}
+}
+
+func TestParseReferenceErrors(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr string
+	}{
+		{input: "", wantErr: "reference is empty"},
+		{input: "golang", wantErr: "expected owner/name"},
+		{input: "https://github.com/golang", wantErr: "expected owner/name"},
+		{input: "golang/go/tree/master", wantErr: `unexpected "tree/master" after owner/name`},
+		{input: "go lang/go", wantErr: `owner "go lang" may only contain`},
+		{input: "golang/go!", wantErr: `name "go!" must be`},
+		{input: "golang/..", wantErr: `name ".." must be`},
+		{input: "https:///golang/go", wantErr: "URL has no host"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := ParseReference(tt.input)
+			require.ErrorContains(t, err, tt.wantErr)
+			require.ErrorIs(t, err, ErrInvalidName)
+		})
+	}
+}
+
+func TestReferenceString(t *testing.T) {
+	require.Equal(t, "golang/go", Reference{Host: DefaultHost, Owner: "golang", Name: "go"}.String())
+	require.Equal(t, "ghe.corp.local/team/svc", Reference{Host: "ghe.corp.local", Owner: "team", Name: "svc"}.String())
+}
+
+func TestNormalizeRepositories(t *testing.T) {
+	got := NormalizeRepositories([]string{
+		"golang/go",
+		"https://github.com/Golang/Go",
+		"git@github.com:golang/go.git",
+		"kubernetes/kubernetes",
+		"not-a-repo",
+		"not-a-repo",
+		"ghe.corp.local/Team/Svc",
+		"https://ghe.corp.local/team/svc/pulls",
+	})
+
+	require.Equal(t, []string{"golang/go", "kubernetes/kubernetes", "not-a-repo", "ghe.corp.local/team/svc"}, got)
+}
@@ -0,0 +1,100 @@
+package github
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+// Major version bumps count as recent when released within this window.
+const majorBumpWindow = 365 * 24 * time.Hour
+
+var semverTag = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+type releaseSummary struct {
+	medianInterval  time.Duration
+	semverShare     float64
+	prereleaseShare float64
+	notesShare      float64
+	majorBumps      int
+}
+
+// releaseStats summarizes the most recent releases, newest first as the
+// repository query returns them: the median time between consecutive
+// releases, the shares of semver tags, pre-releases and releases with notes,
+// and how many releases since now minus majorBumpWindow raised the major
+// version.
+func releaseStats(releases []metrics.ReleaseEdge, now time.Time) releaseSummary {
+	if len(releases) == 0 {
+		return releaseSummary{}
+	}
+
+	var summary releaseSummary
+	var intervals []time.Duration
+	semver, prereleases, notes := 0, 0, 0
+	for i, edge := range releases {
+		release := edge.Node
+		if i > 0 {
+			newer := releases[i-1].Node.PublishedAt.Time
+			if !newer.IsZero() && !release.PublishedAt.IsZero() {
+				intervals = append(intervals, newer.Sub(release.PublishedAt.Time).Abs())
+			}
+		}
+		if semverTag.MatchString(string(release.TagName)) {
+			semver++
+		}
+		if release.IsPrerelease {
+			prereleases++
+		}
+		if strings.TrimSpace(string(release.Description)) != "" {
+			notes++
+		}
+	}
+
+	total := float64(len(releases))
+	summary.medianInterval = medianDuration(intervals)
+	summary.semverShare = float64(semver) / total
+	summary.prereleaseShare = float64(prereleases) / total
+	summary.notesShare = float64(notes) / total
+	summary.majorBumps = majorBumps(releases, now.Add(-majorBumpWindow))
+
+	return summary
+}
+
+// majorBumps walks stable semver releases from oldest to newest and counts
+// the ones published after cutoff whose major version is above the previous
+// release's.
+func majorBumps(releases []metrics.ReleaseEdge, cutoff time.Time) int {
+	bumps := 0
+	previous := -1
+	for i := len(releases) - 1; i >= 0; i-- {
+		release := releases[i].Node
+		if release.IsPrerelease {
+			continue
+		}
+		major, ok := majorVersion(string(release.TagName))
+		if !ok {
+			continue
+		}
+		if previous >= 0 && major > previous && release.PublishedAt.After(cutoff) {
+			bumps++
+		}
+		previous = major
+	}
+	return bumps
+}
+
+func majorVersion(tag string) (int, bool) {
+	match := semverTag.FindStringSubmatch(tag)
+	if match == nil {
+		return 0, false
+	}
+	major, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false
+	}
+	return major, true
+}
@@ -0,0 +1,68 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+func TestReleaseStats(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	release := func(tag string, daysAgo int, prerelease bool, notes string) metrics.ReleaseEdge {
+		return metrics.ReleaseEdge{Node: metrics.ReleaseNode{
+			TagName:      githubv4.String(tag),
+			PublishedAt:  githubv4.DateTime{Time: now.AddDate(0, 0, -daysAgo)},
+			IsPrerelease: githubv4.Boolean(prerelease),
+			Description:  githubv4.String(notes),
+		}}
+	}
+
+	t.Run("no releases", func(t *testing.T) {
+		require.Equal(t, releaseSummary{}, releaseStats(nil, now))
+	})
+
+	t.Run("mixed releases", func(t *testing.T) {
+		releases := []metrics.ReleaseEdge{
+			release("v3.0.0", 10, false, "Breaking changes"),
+			release("v3.0.0-rc.1", 20, true, ""),
+			release("v2.1.0", 50, false, "Features"),
+			release("nightly-2025", 80, false, " "),
+			release("2.0.0", 500, false, "Initial v2"),
+			release("v1.0.0", 700, false, ""),
+		}
+
+		summary := releaseStats(releases, now)
+		require.Equal(t, 30*24*time.Hour, summary.medianInterval)
+		require.InDelta(t, 5.0/6.0, summary.semverShare, 0.0001)
+		require.InDelta(t, 1.0/6.0, summary.prereleaseShare, 0.0001)
+		require.InDelta(t, 3.0/6.0, summary.notesShare, 0.0001)
+		// v1 to v2 happened before the window, v2 to v3 inside it.
+		require.Equal(t, 1, summary.majorBumps)
+	})
+}
+
+func TestMajorVersion(t *testing.T) {
+	tests := []struct {
+		tag    string
+		want   int
+		wantOK bool
+	}{
+		{tag: "v1.2.3", want: 1, wantOK: true},
+		{tag: "10.0.0-beta.1+build.5", want: 10, wantOK: true},
+		{tag: "v1.2", wantOK: false},
+		{tag: "release-1.2.3", wantOK: false},
+		{tag: "v01.2.3", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, ok := majorVersion(tt.tag)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
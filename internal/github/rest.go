@@ -0,0 +1,58 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// restGet fetches a REST API path into v, throttling on the core rate-limit
+// budget and retrying transient failures the same way query does.
+func (c *Client) restGet(ctx context.Context, path string, v interface{}) error {
+	maxAttempts := max(c.retryConfig.MaxAttempts, 1)
+
+	for attempt := 1; ; attempt++ {
+		waited, err := c.waitForBudget(ctx, resourceCore)
+		if err != nil {
+			return err
+		}
+		if waited > 0 {
+			c.metricsRecorder.RecordRateLimitWait(waited)
+		}
+
+		err = c.doRESTGet(ctx, path, v)
+		if err == nil || attempt >= maxAttempts || ctx.Err() != nil {
+			return err
+		}
+
+		reason, transient := classifyError(err)
+		if !transient {
+			return err
+		}
+
+		c.metricsRecorder.RecordRetry(reason)
+		if err := sleepContext(ctx, retryDelay(err, attempt, c.retryConfig)); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) doRESTGet(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.restURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	RetryReasonServerError = "server_error"
+	RetryReasonRateLimited = "rate_limited"
+	RetryReasonTimeout     = "timeout"
+	RetryReasonNetwork     = "network"
+	RetryReasonAuthFailure = "auth_failure"
+)
+
+type RetryConfig struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:    3,
+		InitialBackoff: 1 * time.Second,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// backoff returns a fully jittered exponential delay for the given attempt,
+// starting at 1.
+func (rc RetryConfig) backoff(attempt int) time.Duration {
+	ceiling := rc.InitialBackoff << (attempt - 1)
+	if ceiling <= 0 || ceiling > rc.MaxBackoff {
+		ceiling = rc.MaxBackoff
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(ceiling) + 1))
+}
+
+// HTTPStatusError is returned for non-200 responses from the GitHub API.
+type HTTPStatusError struct {
+	StatusCode int
+	RetryAfter time.Duration
+	Body       string
+	// RateLimitExhausted is set when the response reported no requests left
+	// in the primary rate limit (X-RateLimit-Remaining: 0).
+	RateLimitExhausted bool
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("GitHub API returned %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// statusTransport turns non-200 responses into HTTPStatusError so status code
+// and Retry-After survive the GraphQL client.
+type statusTransport struct {
+	base http.RoundTripper
+}
+
+func (t *statusTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode == http.StatusOK {
+		return resp, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return nil, &HTTPStatusError{
+		StatusCode:         resp.StatusCode,
+		RetryAfter:         parseRetryAfter(resp.Header.Get("Retry-After")),
+		Body:               strings.TrimSpace(string(body)),
+		RateLimitExhausted: resp.Header.Get("X-RateLimit-Remaining") == "0",
+	}
+}
+
+// rateLimited reports whether GitHub refused the request for exceeding a
+// primary or secondary rate limit rather than for a missing permission.
+func (e *HTTPStatusError) rateLimited() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		return e.RetryAfter > 0 || e.RateLimitExhausted || strings.Contains(strings.ToLower(e.Body), "rate limit")
+	default:
+		return false
+	}
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// classifyError reports whether err is worth retrying and why.
+func classifyError(err error) (string, bool) {
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		switch {
+		case statusErr.StatusCode == http.StatusBadGateway,
+			statusErr.StatusCode == http.StatusServiceUnavailable,
+			statusErr.StatusCode == http.StatusGatewayTimeout:
+			return RetryReasonServerError, true
+		case statusErr.StatusCode == http.StatusTooManyRequests:
+			return RetryReasonRateLimited, true
+		case statusErr.StatusCode == http.StatusForbidden &&
+			(statusErr.RetryAfter > 0 || strings.Contains(strings.ToLower(statusErr.Body), "secondary rate limit")):
+			return RetryReasonRateLimited, true
+		default:
+			return "", false
+		}
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return "", false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return RetryReasonTimeout, true
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return RetryReasonNetwork, true
+	}
+
+	// GitHub reports query timeouts as GraphQL errors on a 200 response.
+	if strings.Contains(strings.ToLower(err.Error()), "something went wrong while executing your query") {
+		return RetryReasonTimeout, true
+	}
+
+	return "", false
+}
+
+// retryDelay honors Retry-After up to MaxBackoff and backs off otherwise.
+func retryDelay(err error, attempt int, config RetryConfig) time.Duration {
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		return min(statusErr.RetryAfter, config.MaxBackoff)
+	}
+	return config.backoff(attempt)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Client) SetRetryConfig(config RetryConfig) {
+	c.retryConfig = config
+}
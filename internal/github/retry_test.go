@@ -0,0 +1,121 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantReason    string
+		wantTransient bool
+	}{
+		{name: "statistics pending", err: &HTTPStatusError{StatusCode: 202}, wantTransient: false},
+		{name: "bad gateway", err: &HTTPStatusError{StatusCode: 502}, wantReason: RetryReasonServerError, wantTransient: true},
+		{name: "service unavailable", err: &HTTPStatusError{StatusCode: 503}, wantReason: RetryReasonServerError, wantTransient: true},
+		{name: "too many requests", err: &HTTPStatusError{StatusCode: 429}, wantReason: RetryReasonRateLimited, wantTransient: true},
+		{
+			name:          "secondary rate limit",
+			err:           &HTTPStatusError{StatusCode: 403, Body: "You have exceeded a secondary rate limit"},
+			wantReason:    RetryReasonRateLimited,
+			wantTransient: true,
+		},
+		{name: "forbidden", err: &HTTPStatusError{StatusCode: 403, Body: "Resource not accessible"}, wantTransient: false},
+		{name: "unauthorized", err: &HTTPStatusError{StatusCode: 401}, wantTransient: false},
+		{name: "wrapped status", err: fmt.Errorf("post: %w", &HTTPStatusError{StatusCode: 504}), wantReason: RetryReasonServerError, wantTransient: true},
+		{name: "network timeout", err: timeoutError{}, wantReason: RetryReasonTimeout, wantTransient: true},
+		{name: "unexpected eof", err: io.ErrUnexpectedEOF, wantReason: RetryReasonNetwork, wantTransient: true},
+		{name: "graphql timeout", err: errors.New("Something went wrong while executing your query. This may be the result of a timeout"), wantReason: RetryReasonTimeout, wantTransient: true},
+		{name: "not found", err: errors.New("Could not resolve to a Repository with the name 'a/b'."), wantTransient: false},
+		{name: "message mentioning a timeout", err: errors.New("invalid value for timeout_minutes"), wantTransient: false},
+		{name: "cancelled", err: context.Canceled, wantTransient: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, transient := classifyError(tt.err)
+			require.Equal(t, tt.wantTransient, transient)
+			require.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	require.Equal(t, 30*time.Second, parseRetryAfter("30"))
+	require.Zero(t, parseRetryAfter(""))
+	require.Zero(t, parseRetryAfter("soon"))
+
+	future := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	require.InDelta(t, time.Minute, parseRetryAfter(future), float64(2*time.Second))
+}
+
+func TestRetryBackoff(t *testing.T) {
+	config := RetryConfig{MaxAttempts: 5, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		d := config.backoff(attempt)
+		require.GreaterOrEqual(t, d, time.Duration(0))
+		require.LessOrEqual(t, d, time.Second)
+	}
+	require.LessOrEqual(t, config.backoff(1), 100*time.Millisecond)
+}
+
+func TestRetryDelay(t *testing.T) {
+	config := RetryConfig{MaxAttempts: 3, InitialBackoff: 100 * time.Millisecond, MaxBackoff: 30 * time.Second}
+
+	require.Equal(t, 5*time.Second, retryDelay(&HTTPStatusError{StatusCode: 429, RetryAfter: 5 * time.Second}, 1, config))
+	require.Equal(t, 30*time.Second, retryDelay(&HTTPStatusError{StatusCode: 429, RetryAfter: time.Hour}, 1, config),
+		"Retry-After should be capped at MaxBackoff")
+	require.LessOrEqual(t, retryDelay(&HTTPStatusError{StatusCode: 502}, 1, config), 100*time.Millisecond)
+}
+
+func TestClientRetriesTransientErrors(t *testing.T) {
+	attempts := 0
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"repository": {"owner": {"login": "test"}, "name": "repo"}}}`))
+	})
+	client.SetRetryConfig(RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	repo, err := client.CollectBasicMetrics(context.Background(), "test/repo")
+
+	require.NoError(t, err)
+	require.Equal(t, "repo", repo.Name)
+	require.Equal(t, 3, attempts)
+}
+
+func TestClientDoesNotRetryPermanentErrors(t *testing.T) {
+	attempts := 0
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	client.SetRetryConfig(RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	_, err := client.CollectBasicMetrics(context.Background(), "test/repo")
+
+	var statusErr *HTTPStatusError
+	require.ErrorAs(t, err, &statusErr)
+	require.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+	require.Equal(t, 1, attempts)
+}
@@ -0,0 +1,66 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shurcooL/githubv4"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+const (
+	// MaxSearchResults is the most results GitHub returns for a search,
+	// however many pages are requested.
+	MaxSearchResults = 1000
+
+	DefaultSearchLimit    = 30
+	DefaultMaxSearchLimit = 100
+)
+
+// SearchRepositories runs a GitHub repository search such as
+// "topic:http-router language:go stars:>500" and returns up to limit matching
+// repositories in canonical form, in the order GitHub ranks them.
+func (c *Client) SearchRepositories(ctx context.Context, query string, limit int) ([]string, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, &Error{Code: CodeInvalidName, Err: fmt.Errorf("search query is empty")}
+	}
+	limit = min(limit, MaxSearchResults)
+
+	var repos []string
+	var cursor *githubv4.String
+
+	for len(repos) < limit {
+		var q metrics.SearchRepositoriesQuery
+		variables := map[string]interface{}{
+			metrics.VarQuery:  githubv4.String(query),
+			metrics.VarFirst:  githubv4.Int(min(limit-len(repos), 100)),
+			metrics.VarCursor: cursor,
+		}
+		if err := c.query(ctx, &q, variables, &q.RateLimit); err != nil {
+			return nil, newRepositoryError(query, fmt.Errorf("failed to search repositories for %q: %w", query, err))
+		}
+
+		for _, node := range q.Search.Nodes {
+			ref, err := ParseReference(string(node.Repository.NameWithOwner))
+			if err != nil {
+				continue
+			}
+			ref.Host = c.host
+			repos = append(repos, ref.String())
+		}
+
+		if !q.Search.PageInfo.HasNextPage || len(q.Search.Nodes) == 0 {
+			break
+		}
+		next := q.Search.PageInfo.EndCursor
+		cursor = &next
+	}
+
+	if len(repos) > limit {
+		repos = repos[:limit]
+	}
+	return repos, nil
+}
@@ -0,0 +1,72 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+func TestSearchRepositories(t *testing.T) {
+	var firsts []float64
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "language:go stars:>500", body.Variables[metrics.VarQuery])
+		first := body.Variables[metrics.VarFirst].(float64)
+		firsts = append(firsts, first)
+
+		offset := 0
+		if cursor, ok := body.Variables[metrics.VarCursor].(string); ok {
+			_, _ = fmt.Sscanf(cursor, "offset-%d", &offset)
+		}
+		var nodes []string
+		for i := offset; i < offset+int(first) && i < 250; i++ {
+			nodes = append(nodes, fmt.Sprintf(`{"nameWithOwner": "org/Repo%d"}`, i))
+		}
+		end := offset + len(nodes)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"data": {"search": {"repositoryCount": 250, "nodes": [%s], "pageInfo": {"hasNextPage": %t, "endCursor": "offset-%d"}}}}`,
+			strings.Join(nodes, ","), end < 250, end)
+	})
+	ctx := context.Background()
+
+	t.Run("single page", func(t *testing.T) {
+		firsts = nil
+		repos, err := client.SearchRepositories(ctx, " language:go stars:>500 ", 3)
+		require.NoError(t, err)
+		require.Equal(t, []string{"org/repo0", "org/repo1", "org/repo2"}, repos)
+		require.Equal(t, []float64{3}, firsts)
+	})
+
+	t.Run("pages up to the limit", func(t *testing.T) {
+		firsts = nil
+		repos, err := client.SearchRepositories(ctx, "language:go stars:>500", 150)
+		require.NoError(t, err)
+		require.Len(t, repos, 150)
+		require.Equal(t, "org/repo149", repos[149])
+		require.Equal(t, []float64{100, 50}, firsts)
+	})
+
+	t.Run("fewer results than the limit", func(t *testing.T) {
+		firsts = nil
+		repos, err := client.SearchRepositories(ctx, "language:go stars:>500", 500)
+		require.NoError(t, err)
+		require.Len(t, repos, 250)
+		require.Equal(t, []float64{100, 100, 100}, firsts)
+	})
+
+	t.Run("empty query", func(t *testing.T) {
+		_, err := client.SearchRepositories(ctx, "  ", 10)
+		require.ErrorIs(t, err, ErrInvalidName)
+	})
+}
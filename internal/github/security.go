@@ -0,0 +1,124 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// securitySummary holds the security settings of a repository. Settings the
+// token may not read are left nil, so they can be told apart from disabled
+// ones.
+type securitySummary struct {
+	branchProtected      *bool
+	requiredReviews      int
+	requiresStatusChecks bool
+	vulnerabilityAlerts  *bool
+	advisories           int
+}
+
+// securityPosture checks branch protection and rulesets on branch, whether
+// Dependabot vulnerability alerts are enabled and how many security advisories
+// were published, counting at most 100. Endpoints the token may not read leave
+// their signals unknown instead of failing the repository; GitHub answers 404
+// both for disabled alerts and for tokens that cannot administer the
+// repository, so only admin is trusted with the former.
+func (c *Client) securityPosture(ctx context.Context, ref Reference, branch string, admin bool) (securitySummary, error) {
+	var summary securitySummary
+	repoPath := fmt.Sprintf("/repos/%s/%s", ref.Owner, ref.Name)
+
+	if branch != "" {
+		branchPath := strings.ReplaceAll(url.PathEscape(branch), "%2F", "/")
+
+		var protection struct {
+			Protected  bool `json:"protected"`
+			Protection struct {
+				RequiredStatusChecks struct {
+					EnforcementLevel string   `json:"enforcement_level"`
+					Contexts         []string `json:"contexts"`
+				} `json:"required_status_checks"`
+			} `json:"protection"`
+		}
+		err := c.restGet(ctx, repoPath+"/branches/"+branchPath, &protection)
+		if err := optionalREST(err); err != nil {
+			return summary, newRepositoryError(ref.FullName(), fmt.Errorf("failed to fetch branch protection: %w", err))
+		}
+		protected := false
+		if err == nil {
+			checks := protection.Protection.RequiredStatusChecks
+			protected = protection.Protected
+			summary.branchProtected = &protected
+			summary.requiresStatusChecks = checks.EnforcementLevel != "" && checks.EnforcementLevel != "off" && len(checks.Contexts) > 0
+		}
+
+		var rules []struct {
+			Type       string `json:"type"`
+			Parameters struct {
+				RequiredApprovingReviewCount int `json:"required_approving_review_count"`
+			} `json:"parameters"`
+		}
+		err = c.restGet(ctx, repoPath+"/rules/branches/"+branchPath, &rules)
+		if err := optionalREST(err); err != nil {
+			return summary, newRepositoryError(ref.FullName(), fmt.Errorf("failed to fetch branch rules: %w", err))
+		}
+		if err == nil {
+			summary.branchProtected = &protected
+		}
+		for _, rule := range rules {
+			switch rule.Type {
+			case "pull_request":
+				protected = true
+				summary.requiredReviews = max(summary.requiredReviews, rule.Parameters.RequiredApprovingReviewCount)
+			case "required_status_checks":
+				protected = true
+				summary.requiresStatusChecks = true
+			case "deletion", "non_fast_forward", "update", "required_signatures", "required_linear_history":
+				protected = true
+			}
+		}
+	}
+
+	// GitHub answers 204 when alerts are enabled and 404 otherwise.
+	var statusErr *HTTPStatusError
+	err := c.restGet(ctx, repoPath+"/vulnerability-alerts", &struct{}{})
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNoContent {
+		enabled := true
+		summary.vulnerabilityAlerts = &enabled
+	} else if err := optionalREST(err); err != nil {
+		return summary, newRepositoryError(ref.FullName(), fmt.Errorf("failed to fetch vulnerability alerts: %w", err))
+	} else if admin && statusErr != nil && statusErr.StatusCode == http.StatusNotFound {
+		enabled := false
+		summary.vulnerabilityAlerts = &enabled
+	}
+
+	var advisories []struct {
+		GHSAID string `json:"ghsa_id"`
+	}
+	err = c.restGet(ctx, repoPath+"/security-advisories?state=published&per_page=100", &advisories)
+	if err := optionalREST(err); err != nil {
+		return summary, newRepositoryError(ref.FullName(), fmt.Errorf("failed to fetch security advisories: %w", err))
+	}
+	summary.advisories = len(advisories)
+
+	return summary, nil
+}
+
+// optionalREST drops errors for resources the token cannot see: GitHub hides
+// admin-only settings behind 404, and a 403 that is not a rate limit means
+// the token lacks the permission.
+func optionalREST(err error) error {
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) {
+		return err
+	}
+	if statusErr.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if statusErr.StatusCode == http.StatusForbidden && !statusErr.rateLimited() {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,144 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecurityPosture(t *testing.T) {
+	graphql := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"repository": {
+			"owner": {"login": "test"}, "name": "repo",
+			"defaultBranchRef": {"name": "release/v1"},
+			"docsDir": {"entries": [{"name": "SECURITY.md"}]}
+		}}}`))
+	}
+
+	t.Run("protected", func(t *testing.T) {
+		var paths []string
+		client := newRESTTestClient(t, graphql, func(w http.ResponseWriter, r *http.Request) {
+			paths = append(paths, r.URL.Path)
+			switch r.URL.Path {
+			case "/repos/test/repo/branches/release/v1":
+				_, _ = w.Write([]byte(`{"protected": true, "protection": {"required_status_checks": {"enforcement_level": "non_admins", "contexts": ["ci"]}}}`))
+			case "/repos/test/repo/rules/branches/release/v1":
+				_, _ = w.Write([]byte(`[{"type": "deletion"}, {"type": "pull_request", "parameters": {"required_approving_review_count": 2}}]`))
+			case "/repos/test/repo/vulnerability-alerts":
+				w.WriteHeader(http.StatusNoContent)
+			case "/repos/test/repo/security-advisories":
+				require.Equal(t, "published", r.URL.Query().Get("state"))
+				_, _ = w.Write([]byte(`[{"ghsa_id": "GHSA-1"}, {"ghsa_id": "GHSA-2"}]`))
+			default:
+				defaultREST(w, r)
+			}
+		})
+
+		repo, err := client.CollectBasicMetrics(context.Background(), "test/repo")
+		require.NoError(t, err)
+		require.Equal(t, "release/v1", repo.DefaultBranch)
+		require.NotNil(t, repo.BranchProtected)
+		require.True(t, *repo.BranchProtected)
+		require.Equal(t, 2, repo.RequiredReviews)
+		require.True(t, repo.RequiresStatusChecks)
+		require.NotNil(t, repo.VulnerabilityAlerts)
+		require.True(t, *repo.VulnerabilityAlerts)
+		require.Equal(t, 2, repo.SecurityAdvisories)
+		require.True(t, repo.HasSecurity)
+		require.Contains(t, paths, "/repos/test/repo/rules/branches/release/v1")
+	})
+
+	t.Run("settings hidden from the token", func(t *testing.T) {
+		client := newRESTTestClient(t, graphql, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/repos/test/repo/stats/participation":
+				defaultREST(w, r)
+			case "/repos/test/repo/security-advisories":
+				w.WriteHeader(http.StatusForbidden)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})
+
+		repo, err := client.CollectBasicMetrics(context.Background(), "test/repo")
+		require.NoError(t, err)
+		require.Nil(t, repo.BranchProtected)
+		require.Nil(t, repo.VulnerabilityAlerts)
+		require.Zero(t, repo.SecurityAdvisories)
+	})
+
+	t.Run("disabled on an administered repository", func(t *testing.T) {
+		admin := func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data": {"repository": {
+				"owner": {"login": "test"}, "name": "repo",
+				"viewerPermission": "ADMIN",
+				"defaultBranchRef": {"name": "main"}
+			}}}`))
+		}
+		client := newRESTTestClient(t, admin, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/repos/test/repo/branches/main":
+				_, _ = w.Write([]byte(`{"protected": false}`))
+			case "/repos/test/repo/rules/branches/main":
+				_, _ = w.Write([]byte(`[]`))
+			case "/repos/test/repo/vulnerability-alerts":
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				defaultREST(w, r)
+			}
+		})
+
+		repo, err := client.CollectBasicMetrics(context.Background(), "test/repo")
+		require.NoError(t, err)
+		require.True(t, repo.ViewerCanAdminister)
+		require.NotNil(t, repo.BranchProtected)
+		require.False(t, *repo.BranchProtected)
+		require.NotNil(t, repo.VulnerabilityAlerts)
+		require.False(t, *repo.VulnerabilityAlerts)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		client := newRESTTestClient(t, graphql, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/repos/test/repo/vulnerability-alerts" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message":"API rate limit exceeded"}`))
+				return
+			}
+			defaultREST(w, r)
+		})
+
+		_, err := client.CollectBasicMetrics(context.Background(), "test/repo")
+		require.ErrorContains(t, err, "failed to fetch vulnerability alerts")
+	})
+
+	t.Run("rate limit exhausted", func(t *testing.T) {
+		client := newRESTTestClient(t, graphql, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/repos/test/repo/security-advisories" {
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			defaultREST(w, r)
+		})
+
+		_, err := client.CollectBasicMetrics(context.Background(), "test/repo")
+		require.ErrorContains(t, err, "failed to fetch security advisories")
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		client := newRESTTestClient(t, graphql, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/repos/test/repo/vulnerability-alerts" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			defaultREST(w, r)
+		})
+
+		_, err := client.CollectBasicMetrics(context.Background(), "test/repo")
+		require.ErrorContains(t, err, "failed to fetch vulnerability alerts")
+	})
+}
@@ -0,0 +1,187 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+// A token that fails authentication is set aside for this long.
+const tokenBenchDuration = 15 * time.Minute
+
+var ErrNoUsableToken = errors.New("all GitHub tokens are set aside after authentication failures")
+
+type pooledToken struct {
+	label string
+	token string
+	// GraphQL queries and REST calls draw from separate budgets.
+	graphql       tokenBudget
+	core          tokenBudget
+	benchedUntil  time.Time
+	inFlightCount int
+}
+
+func (pt *pooledToken) budget(resource string) *tokenBudget {
+	if resource == resourceCore {
+		return &pt.core
+	}
+	return &pt.graphql
+}
+
+type tokenBudget struct {
+	remaining int
+	resetAt   time.Time
+}
+
+// left returns the remaining points, treating unknown or reset windows as full.
+func (b *tokenBudget) left(now time.Time) int {
+	if b.resetAt.IsZero() || !now.Before(b.resetAt) {
+		return int(^uint(0) >> 1)
+	}
+	return b.remaining
+}
+
+// tokenPool spreads requests over several tokens, always using the one with the
+// most rate-limit budget left.
+type tokenPool struct {
+	mu              sync.Mutex
+	tokens          []*pooledToken
+	metricsRecorder metrics.Recorder
+	now             func() time.Time
+}
+
+// newTokenPool pools the tokens of host. Tokens are labeled by host and
+// position, as in github.com/token-1, so metrics never expose them and stay
+// apart across hosts.
+func newTokenPool(host string, tokens []string) *tokenPool {
+	pool := &tokenPool{
+		metricsRecorder: &metrics.NoOpRecorder{},
+		now:             time.Now,
+	}
+	for i, token := range tokens {
+		pool.tokens = append(pool.tokens, &pooledToken{
+			label: fmt.Sprintf("%s/token-%d", host, i+1),
+			token: token,
+		})
+	}
+	return pool
+}
+
+func (p *tokenPool) setMetricsRecorder(recorder metrics.Recorder) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.metricsRecorder = recorder
+}
+
+// pick returns the token with the most budget left for resource.
+func (p *tokenPool) pick(resource string) (*pooledToken, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	var best *pooledToken
+	for _, pt := range p.tokens {
+		if now.Before(pt.benchedUntil) {
+			continue
+		}
+		left := pt.budget(resource).left(now)
+		if best == nil ||
+			left > best.budget(resource).left(now) ||
+			(left == best.budget(resource).left(now) && pt.inFlightCount < best.inFlightCount) {
+			best = pt
+		}
+	}
+	if best == nil {
+		return nil, ErrNoUsableToken
+	}
+
+	best.inFlightCount++
+	p.metricsRecorder.RecordTokenRequest(best.label)
+	return best, nil
+}
+
+func (p *tokenPool) release(pt *pooledToken, resource string, resp *http.Response) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pt.inFlightCount--
+	if resp == nil {
+		return
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		pt.benchedUntil = p.now().Add(tokenBenchDuration)
+		p.metricsRecorder.RecordTokenAuthFailure(pt.label)
+		return
+	}
+
+	// Some REST endpoints, such as search, have budgets of their own.
+	if name := resp.Header.Get("X-RateLimit-Resource"); name != "" && name != resource {
+		return
+	}
+
+	state, ok := headerRateLimit(resp.Header)
+	if !ok {
+		return
+	}
+	budget := pt.budget(resource)
+	budget.remaining = int(state.Remaining)
+	budget.resetAt = state.ResetAt.Time
+	if resource == resourceGraphQL {
+		p.metricsRecorder.RecordTokenRateLimit(pt.label, budget.remaining)
+	}
+}
+
+// delay returns how long to wait before any token has more than reserve
+// points of resource.
+func (p *tokenPool) delay(resource string, reserve int) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	var wait time.Duration
+	for _, pt := range p.tokens {
+		if now.Before(pt.benchedUntil) {
+			continue
+		}
+		budget := pt.budget(resource)
+		if budget.left(now) > reserve {
+			return 0
+		}
+		if d := budget.resetAt.Sub(now); wait == 0 || d < wait {
+			wait = d
+		}
+	}
+	return wait
+}
+
+// poolTransport authenticates each request with a token from the pool and
+// feeds the response's rate-limit headers back into it.
+type poolTransport struct {
+	base http.RoundTripper
+	pool *tokenPool
+}
+
+func (t *poolTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource := requestResource(req)
+	pt, err := t.pool.pick(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	authed := req.Clone(req.Context())
+	authed.Header.Set("Authorization", "Bearer "+pt.token)
+
+	resp, err := t.base.RoundTrip(authed)
+	t.pool.release(pt, resource, resp)
+	return resp, err
+}
+
+func isUnauthorized(err error) bool {
+	var statusErr *HTTPStatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusUnauthorized
+}
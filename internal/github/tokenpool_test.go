@@ -0,0 +1,135 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func rateLimitResponse(status, remaining int, reset time.Time) *http.Response {
+	header := http.Header{}
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	return &http.Response{StatusCode: status, Header: header}
+}
+
+func TestTokenPoolPick(t *testing.T) {
+	now := time.Now()
+	pool := newTokenPool(DefaultHost, []string{"a", "b", "c"})
+
+	first, err := pool.pick(resourceGraphQL)
+	require.NoError(t, err)
+	pool.release(first, resourceGraphQL, rateLimitResponse(http.StatusOK, 100, now.Add(time.Hour)))
+
+	second, err := pool.pick(resourceGraphQL)
+	require.NoError(t, err)
+	require.NotEqual(t, first.token, second.token, "unused tokens have more budget than a used one")
+	pool.release(second, resourceGraphQL, rateLimitResponse(http.StatusOK, 4000, now.Add(time.Hour)))
+
+	third, err := pool.pick(resourceGraphQL)
+	require.NoError(t, err)
+	pool.release(third, resourceGraphQL, rateLimitResponse(http.StatusOK, 2000, now.Add(time.Hour)))
+
+	best, err := pool.pick(resourceGraphQL)
+	require.NoError(t, err)
+	require.Equal(t, second.token, best.token, "token with the most remaining budget should be picked")
+	require.Equal(t, "github.com/token-2", best.label)
+	pool.release(best, resourceGraphQL, nil)
+}
+
+func TestTokenPoolTracksBudgetsPerResource(t *testing.T) {
+	now := time.Now()
+	pool := newTokenPool(DefaultHost, []string{"a", "b"})
+	pool.now = func() time.Time { return now }
+
+	first, err := pool.pick(resourceCore)
+	require.NoError(t, err)
+	core := rateLimitResponse(http.StatusOK, 10, now.Add(time.Hour))
+	core.Header.Set("X-RateLimit-Resource", resourceCore)
+	pool.release(first, resourceCore, core)
+
+	require.Equal(t, 10, first.core.remaining)
+	require.Zero(t, first.graphql.remaining, "REST calls should not touch the GraphQL budget")
+
+	second, err := pool.pick(resourceCore)
+	require.NoError(t, err)
+	require.NotEqual(t, first.token, second.token, "token with the most core budget should serve REST calls")
+	search := rateLimitResponse(http.StatusOK, 0, now.Add(time.Minute))
+	search.Header.Set("X-RateLimit-Resource", "search")
+	pool.release(second, resourceCore, search)
+
+	require.Zero(t, second.core.resetAt, "budgets of other REST resources should be ignored")
+	require.Zero(t, pool.delay(resourceCore, 50))
+	require.Zero(t, pool.delay(resourceGraphQL, 50))
+
+	second.core.remaining, second.core.resetAt = 0, now.Add(time.Minute)
+	require.Equal(t, time.Minute, pool.delay(resourceCore, 50))
+}
+
+func TestTokenPoolBenchesUnauthorizedTokens(t *testing.T) {
+	pool := newTokenPool(DefaultHost, []string{"bad"})
+
+	pt, err := pool.pick(resourceGraphQL)
+	require.NoError(t, err)
+	pool.release(pt, resourceGraphQL, &http.Response{StatusCode: http.StatusUnauthorized, Header: http.Header{}})
+
+	_, err = pool.pick(resourceGraphQL)
+	require.ErrorIs(t, err, ErrNoUsableToken)
+
+	pool.now = func() time.Time { return time.Now().Add(tokenBenchDuration + time.Minute) }
+	_, err = pool.pick(resourceGraphQL)
+	require.NoError(t, err, "token should return after the bench period")
+}
+
+func TestTokenPoolDelay(t *testing.T) {
+	now := time.Now()
+	pool := newTokenPool(DefaultHost, []string{"a", "b"})
+	pool.now = func() time.Time { return now }
+	pool.tokens[0].graphql.remaining, pool.tokens[0].graphql.resetAt = 10, now.Add(20*time.Minute)
+	pool.tokens[1].graphql.remaining, pool.tokens[1].graphql.resetAt = 10, now.Add(5*time.Minute)
+
+	require.Equal(t, 5*time.Minute, pool.delay(resourceGraphQL, 50))
+
+	pool.tokens[1].graphql.remaining = 500
+	require.Zero(t, pool.delay(resourceGraphQL, 50))
+}
+
+func TestClientRotatesPastUnauthorizedToken(t *testing.T) {
+	var seen []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if r.URL.Path == "/" {
+			seen = append(seen, auth)
+		}
+		if auth == "Bearer revoked" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/repos/") {
+			defaultREST(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		_, _ = w.Write([]byte(`{"data": {"repository": {"owner": {"login": "test"}, "name": "repo"}}}`))
+	}))
+	defer srv.Close()
+
+	pool := newTokenPool(DefaultHost, []string{"revoked", "valid"})
+	client := newClient(HostConfig{APIURL: srv.URL, RESTURL: srv.URL}, &poolTransport{base: srv.Client().Transport, pool: pool})
+	client.tokenPool = pool
+	client.SetRetryConfig(RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	repo, err := client.CollectBasicMetrics(context.Background(), "test/repo")
+
+	require.NoError(t, err)
+	require.Equal(t, "repo", repo.Name)
+	require.Equal(t, []string{"Bearer revoked", "Bearer valid"}, seen)
+}
@@ -0,0 +1,79 @@
+package gomod
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/mod/modfile"
+)
+
+// Module is a dependency required by a go.mod file. When a replace directive
+// swaps it for another module, Path is the replacement, whose code is what
+// actually gets built, and Required is the path as required.
+type Module struct {
+	Path     string
+	Version  string
+	Required string
+	Indirect bool
+	// LocalReplacement is the directory a replace directive points the
+	// module to, which leaves no repository to score.
+	LocalReplacement string
+}
+
+// ReadFile parses the go.mod file at path. See Parse.
+func ReadFile(path string, indirect bool) ([]Module, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return Parse(path, data, indirect)
+}
+
+// Parse lists the modules required by a go.mod file, in file order. Indirect
+// requirements are only listed when indirect is set.
+func Parse(path string, data []byte, indirect bool) ([]Module, error) {
+	file, err := modfile.Parse(path, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	var modules []Module
+	for _, req := range file.Require {
+		if req.Indirect && !indirect {
+			continue
+		}
+		module := Module{
+			Path:     req.Mod.Path,
+			Version:  req.Mod.Version,
+			Required: req.Mod.Path,
+			Indirect: req.Indirect,
+		}
+		if replace := replacement(file.Replace, req.Mod.Path, req.Mod.Version); replace != nil {
+			if replace.New.Version == "" {
+				module.LocalReplacement = replace.New.Path
+			} else {
+				module.Path, module.Version = replace.New.Path, replace.New.Version
+			}
+		}
+		modules = append(modules, module)
+	}
+	return modules, nil
+}
+
+// replacement finds the replace directive for path at version. A directive
+// for that exact version wins over one for every version.
+func replacement(replaces []*modfile.Replace, path, version string) *modfile.Replace {
+	var match *modfile.Replace
+	for _, replace := range replaces {
+		if replace.Old.Path != path {
+			continue
+		}
+		if replace.Old.Version == version {
+			return replace
+		}
+		if replace.Old.Version == "" {
+			match = replace
+		}
+	}
+	return match
+}
@@ -0,0 +1,64 @@
+package gomod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testGoMod = `module example.com/service
+
+go 1.24
+
+require (
+	github.com/spf13/cobra v1.9.1
+	gopkg.in/yaml.v3 v3.0.1
+	example.com/internal/lib v0.1.0
+	github.com/old/fork v1.0.0
+)
+
+require github.com/davecgh/go-spew v1.1.1 // indirect
+
+replace github.com/old/fork => github.com/new/fork v1.2.0
+
+replace example.com/internal/lib => ../lib
+`
+
+func TestParse(t *testing.T) {
+	t.Run("direct", func(t *testing.T) {
+		modules, err := Parse("go.mod", []byte(testGoMod), false)
+		require.NoError(t, err)
+		require.Equal(t, []Module{
+			{Path: "github.com/spf13/cobra", Version: "v1.9.1", Required: "github.com/spf13/cobra"},
+			{Path: "gopkg.in/yaml.v3", Version: "v3.0.1", Required: "gopkg.in/yaml.v3"},
+			{Path: "example.com/internal/lib", Version: "v0.1.0", Required: "example.com/internal/lib", LocalReplacement: "../lib"},
+			{Path: "github.com/new/fork", Version: "v1.2.0", Required: "github.com/old/fork"},
+		}, modules)
+	})
+
+	t.Run("with indirect", func(t *testing.T) {
+		modules, err := Parse("go.mod", []byte(testGoMod), true)
+		require.NoError(t, err)
+		require.Len(t, modules, 5)
+		require.Equal(t, Module{Path: "github.com/davecgh/go-spew", Version: "v1.1.1", Required: "github.com/davecgh/go-spew", Indirect: true}, modules[4])
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Parse("go.mod", []byte("require (\n"), false)
+		require.ErrorContains(t, err, "failed to parse go.mod")
+	})
+}
+
+func TestReplacementPrefersExactVersion(t *testing.T) {
+	data := []byte(`module m
+
+require github.com/a/b v1.0.0
+
+replace github.com/a/b => github.com/c/b v1.1.0
+
+replace github.com/a/b v1.0.0 => github.com/d/b v1.0.1
+`)
+	modules, err := Parse("go.mod", data, false)
+	require.NoError(t, err)
+	require.Equal(t, "github.com/d/b", modules[0].Path)
+}
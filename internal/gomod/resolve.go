@@ -0,0 +1,234 @@
+package gomod
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kdimtriCP/gh-inspector/internal/github"
+)
+
+const (
+	// DefaultResolveTimeout bounds each go-import lookup.
+	DefaultResolveTimeout = 10 * time.Second
+	// ResolveWorkers bounds how many lookups run at the same time.
+	ResolveWorkers = 8
+)
+
+// mirrors maps modules whose go-import tags point off GitHub, mostly to
+// go.googlesource.com, onto their GitHub mirror. Entries cover the module and
+// the packages below it.
+var mirrors = map[string]string{
+	"golang.org/x/arch":          "github.com/golang/arch",
+	"golang.org/x/crypto":        "github.com/golang/crypto",
+	"golang.org/x/exp":           "github.com/golang/exp",
+	"golang.org/x/image":         "github.com/golang/image",
+	"golang.org/x/mobile":        "github.com/golang/mobile",
+	"golang.org/x/mod":           "github.com/golang/mod",
+	"golang.org/x/net":           "github.com/golang/net",
+	"golang.org/x/oauth2":        "github.com/golang/oauth2",
+	"golang.org/x/sync":          "github.com/golang/sync",
+	"golang.org/x/sys":           "github.com/golang/sys",
+	"golang.org/x/telemetry":     "github.com/golang/telemetry",
+	"golang.org/x/term":          "github.com/golang/term",
+	"golang.org/x/text":          "github.com/golang/text",
+	"golang.org/x/time":          "github.com/golang/time",
+	"golang.org/x/tools":         "github.com/golang/tools",
+	"golang.org/x/vuln":          "github.com/golang/vuln",
+	"golang.org/x/xerrors":       "github.com/golang/xerrors",
+	"google.golang.org/protobuf": "github.com/protocolbuffers/protobuf-go",
+}
+
+// gopkgIn matches a gopkg.in path element carrying the major version, such as
+// yaml.v3.
+var gopkgIn = regexp.MustCompile(`^([A-Za-z0-9_.-]+)\.v[0-9]+(-unstable)?$`)
+
+// Resolution is the repository a module maps to, or why it maps to none.
+type Resolution struct {
+	Module     Module
+	Repository string
+	Err        error
+}
+
+// Resolver maps module paths to GitHub repositories. Paths under github.com
+// map directly, gopkg.in paths follow its naming conventions and modules known
+// to be mirrored to GitHub map to the mirror; other vanity import paths are
+// resolved through their go-import meta tags, the way the go command discovers
+// the repository.
+type Resolver struct {
+	client *http.Client
+	scheme string
+}
+
+func NewResolver(client *http.Client) *Resolver {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultResolveTimeout}
+	}
+	return &Resolver{client: client, scheme: "https"}
+}
+
+// ResolveAll resolves modules in parallel, keeping their order.
+func (r *Resolver) ResolveAll(ctx context.Context, modules []Module) []Resolution {
+	resolutions := make([]Resolution, len(modules))
+	github.RunPool(len(modules), ResolveWorkers, func(i int) {
+		module := modules[i]
+		resolution := Resolution{Module: module}
+		if module.LocalReplacement != "" {
+			resolution.Err = fmt.Errorf("replaced by local directory %s", module.LocalReplacement)
+		} else {
+			resolution.Repository, resolution.Err = r.Repository(ctx, module.Path)
+		}
+		resolutions[i] = resolution
+	})
+	return resolutions
+}
+
+// Repository returns the GitHub repository hosting the module at path, in
+// canonical form.
+func (r *Resolver) Repository(ctx context.Context, path string) (string, error) {
+	first, _, _ := strings.Cut(path, "/")
+	if !strings.Contains(first, ".") {
+		return "", fmt.Errorf("%s is not a remote module path", path)
+	}
+
+	if first == github.DefaultHost {
+		segments := strings.SplitN(path, "/", 4)
+		if len(segments) < 3 {
+			return "", fmt.Errorf("%s does not name a repository", path)
+		}
+		return repositoryFor(strings.Join(segments[:3], "/"))
+	}
+	if first == "gopkg.in" {
+		return gopkgInRepository(path)
+	}
+	for module, mirror := range mirrors {
+		if path == module || strings.HasPrefix(path, module+"/") {
+			return repositoryFor(mirror)
+		}
+	}
+
+	imports, err := r.goImports(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	for _, imp := range imports {
+		if imp.vcs != "git" || (imp.prefix != path && !strings.HasPrefix(path, imp.prefix+"/")) {
+			continue
+		}
+		return repositoryFor(imp.repoRoot)
+	}
+	return "", fmt.Errorf("no go-import meta tag for %s served by https://%s?go-get=1", path, path)
+}
+
+// gopkgInRepository maps gopkg.in/pkg.vN to github.com/go-pkg/pkg and
+// gopkg.in/user/pkg.vN to github.com/user/pkg.
+func gopkgInRepository(path string) (string, error) {
+	elements := strings.Split(path, "/")[1:]
+	if len(elements) == 0 {
+		return "", fmt.Errorf("%s does not name a repository", path)
+	}
+	if match := gopkgIn.FindStringSubmatch(elements[0]); match != nil {
+		return repositoryFor("github.com/go-" + match[1] + "/" + match[1])
+	}
+	if len(elements) > 1 {
+		if match := gopkgIn.FindStringSubmatch(elements[1]); match != nil {
+			return repositoryFor("github.com/" + elements[0] + "/" + match[1])
+		}
+	}
+	return "", fmt.Errorf("%s does not follow the gopkg.in naming conventions", path)
+}
+
+func repositoryFor(repoRoot string) (string, error) {
+	host, _, _ := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(repoRoot, "https://"), "http://"), "/")
+	if !strings.EqualFold(host, github.DefaultHost) {
+		return "", fmt.Errorf("repository %s is not hosted on GitHub", repoRoot)
+	}
+	ref, err := github.ParseReference(repoRoot)
+	if err != nil {
+		return "", fmt.Errorf("repository %s: %w", repoRoot, err)
+	}
+	return ref.String(), nil
+}
+
+type goImport struct {
+	prefix, vcs, repoRoot string
+}
+
+func (r *Resolver) goImports(ctx context.Context, path string) ([]goImport, error) {
+	url := fmt.Sprintf("%s://%s?go-get=1", r.scheme, path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Like the go command, read the meta tags even from error pages, since
+	// some servers answer go-get requests for subpackages with a 404.
+	imports, err := parseGoImports(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", url, err)
+	}
+	return imports, nil
+}
+
+// parseGoImports reads the go-import meta tags in the head of an HTML page.
+func parseGoImports(r io.Reader) ([]goImport, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		if strings.EqualFold(charset, "utf-8") || strings.EqualFold(charset, "ascii") {
+			return input, nil
+		}
+		return nil, fmt.Errorf("can't decode XML document using charset %q", charset)
+	}
+
+	var imports []goImport
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			return imports, nil
+		}
+		if err != nil {
+			if len(imports) > 0 {
+				return imports, nil
+			}
+			return nil, err
+		}
+		if end, ok := token.(xml.EndElement); ok && strings.EqualFold(end.Name.Local, "head") {
+			return imports, nil
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if strings.EqualFold(start.Name.Local, "body") {
+			return imports, nil
+		}
+		if !strings.EqualFold(start.Name.Local, "meta") || attr(start, "name") != "go-import" {
+			continue
+		}
+		if fields := strings.Fields(attr(start, "content")); len(fields) == 3 {
+			imports = append(imports, goImport{prefix: fields[0], vcs: fields[1], repoRoot: fields[2]})
+		}
+	}
+}
+
+func attr(element xml.StartElement, name string) string {
+	for _, a := range element.Attr {
+		if strings.EqualFold(a.Name.Local, name) {
+			return a.Value
+		}
+	}
+	return ""
+}
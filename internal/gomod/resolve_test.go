@@ -0,0 +1,100 @@
+package gomod
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveAll(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "1", r.URL.Query().Get("go-get"))
+		host := r.Host
+		switch r.URL.Path {
+		case "/yaml":
+			_, _ = w.Write([]byte(`<html><head>
+<meta name="go-import" content="` + host + `/yaml git https://github.com/Go-YAML/yaml">
+<meta name="go-source" content="` + host + `/yaml _ _ _">
+</head><body>go get</body></html>`))
+		case "/x/mod":
+			_, _ = w.Write([]byte(`<!DOCTYPE html><html><head><meta name="go-import" content="` + host + `/x/mod git https://go.googlesource.com/mod"></head></html>`))
+		case "/kit/log":
+			// Subpackages are often answered with the repository root tag.
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<meta name="go-import" content="` + host + `/kit mod https://proxy.example.com">
+<meta name="go-import" content="` + host + `/kit git https://github.com/example/kit.git">`))
+		case "/gitlab":
+			_, _ = w.Write([]byte(`<meta name="go-import" content="` + host + `/gitlab git https://gitlab.com/group/project">`))
+		default:
+			_, _ = w.Write([]byte(`<html><body>nothing here</body></html>`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	resolver := NewResolver(srv.Client())
+	resolver.scheme = "http"
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	modules := []Module{
+		{Path: "github.com/spf13/cobra"},
+		{Path: "github.com/aws/aws-sdk-go-v2/service/s3"},
+		{Path: host + "/yaml"},
+		{Path: host + "/x/mod"},
+		{Path: "golang.org/x/mod"},
+		{Path: "golang.org/x/tools/go/packages"},
+		{Path: "google.golang.org/protobuf"},
+		{Path: "gopkg.in/yaml.v3"},
+		{Path: "gopkg.in/src-d/go-git.v4/plumbing"},
+		{Path: "gopkg.in/yaml"},
+		{Path: host + "/kit/log"},
+		{Path: host + "/gitlab"},
+		{Path: host + "/missing"},
+		{Path: "example.com/lib", LocalReplacement: "../lib"},
+		{Path: "github.com/spf13"},
+		{Path: "internal/tools"},
+	}
+	resolutions := resolver.ResolveAll(context.Background(), modules)
+	require.Len(t, resolutions, len(modules))
+
+	repos := make([]string, len(resolutions))
+	for i, resolution := range resolutions {
+		require.Equal(t, modules[i], resolution.Module)
+		repos[i] = resolution.Repository
+	}
+	require.Equal(t, []string{
+		"spf13/cobra",
+		"aws/aws-sdk-go-v2",
+		"go-yaml/yaml",
+		"",
+		"golang/mod",
+		"golang/tools",
+		"protocolbuffers/protobuf-go",
+		"go-yaml/yaml",
+		"src-d/go-git",
+		"",
+		"example/kit",
+		"", "", "", "", "",
+	}, repos)
+
+	require.ErrorContains(t, resolutions[3].Err, "https://go.googlesource.com/mod is not hosted on GitHub")
+	require.ErrorContains(t, resolutions[9].Err, "does not follow the gopkg.in naming conventions")
+	require.ErrorContains(t, resolutions[11].Err, "https://gitlab.com/group/project is not hosted on GitHub")
+	require.ErrorContains(t, resolutions[12].Err, "no go-import meta tag for "+host+"/missing")
+	require.ErrorContains(t, resolutions[13].Err, "replaced by local directory ../lib")
+	require.ErrorContains(t, resolutions[14].Err, "does not name a repository")
+	require.ErrorContains(t, resolutions[15].Err, "not a remote module path")
+}
+
+func TestResolveUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	resolver := NewResolver(nil)
+	resolver.scheme = "http"
+	_, err := resolver.Repository(context.Background(), strings.TrimPrefix(srv.URL, "http://")+"/mod")
+	require.ErrorContains(t, err, "failed to resolve")
+}
@@ -0,0 +1,156 @@
+package license
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Class string
+
+const (
+	ClassPermissive     Class = "permissive"
+	ClassWeakCopyleft   Class = "weak-copyleft"
+	ClassStrongCopyleft Class = "strong-copyleft"
+	ClassUnknown        Class = "unknown"
+	// ClassNone marks repositories without any detected license.
+	ClassNone Class = "none"
+)
+
+// NoAssertion is the SPDX identifier GitHub reports for licenses it detected
+// but could not identify.
+const NoAssertion = "NOASSERTION"
+
+var classes = map[string]Class{
+	"0BSD":               ClassPermissive,
+	"AFL-3.0":            ClassPermissive,
+	"APACHE-2.0":         ClassPermissive,
+	"ARTISTIC-2.0":       ClassPermissive,
+	"BSD-2-CLAUSE":       ClassPermissive,
+	"BSD-3-CLAUSE":       ClassPermissive,
+	"BSD-3-CLAUSE-CLEAR": ClassPermissive,
+	"BSD-4-CLAUSE":       ClassPermissive,
+	"BSL-1.0":            ClassPermissive,
+	"CC-BY-4.0":          ClassPermissive,
+	"CC0-1.0":            ClassPermissive,
+	"ECL-2.0":            ClassPermissive,
+	"ISC":                ClassPermissive,
+	"MIT":                ClassPermissive,
+	"MIT-0":              ClassPermissive,
+	"NCSA":               ClassPermissive,
+	"POSTGRESQL":         ClassPermissive,
+	"PYTHON-2.0":         ClassPermissive,
+	"UNLICENSE":          ClassPermissive,
+	"UPL-1.0":            ClassPermissive,
+	"WTFPL":              ClassPermissive,
+	"ZLIB":               ClassPermissive,
+
+	"CDDL-1.0": ClassWeakCopyleft,
+	"CDDL-1.1": ClassWeakCopyleft,
+	"EPL-1.0":  ClassWeakCopyleft,
+	"EPL-2.0":  ClassWeakCopyleft,
+	"LGPL-2.0": ClassWeakCopyleft,
+	"LGPL-2.1": ClassWeakCopyleft,
+	"LGPL-3.0": ClassWeakCopyleft,
+	"MPL-2.0":  ClassWeakCopyleft,
+	"MS-RL":    ClassWeakCopyleft,
+	"OFL-1.1":  ClassWeakCopyleft,
+
+	"AGPL-3.0":   ClassStrongCopyleft,
+	"CECILL-2.1": ClassStrongCopyleft,
+	"EUPL-1.1":   ClassStrongCopyleft,
+	"EUPL-1.2":   ClassStrongCopyleft,
+	"GPL-2.0":    ClassStrongCopyleft,
+	"GPL-3.0":    ClassStrongCopyleft,
+	"OSL-3.0":    ClassStrongCopyleft,
+	"SSPL-1.0":   ClassStrongCopyleft,
+}
+
+// Classify maps an SPDX identifier to its license family. The -only and
+// -or-later variants share the class of the base license.
+func Classify(spdxID string) Class {
+	if spdxID == "" {
+		return ClassNone
+	}
+	if class, ok := classes[baseID(spdxID)]; ok {
+		return class
+	}
+	return ClassUnknown
+}
+
+func baseID(spdxID string) string {
+	id := strings.ToUpper(strings.TrimSpace(spdxID))
+	id = strings.TrimSuffix(id, "+")
+	id = strings.TrimSuffix(id, "-ONLY")
+	return strings.TrimSuffix(id, "-OR-LATER")
+}
+
+const (
+	ActionZero = "zero"
+	ActionCap  = "cap"
+)
+
+// Policy decides which licenses are acceptable. Entries in Allow and Deny are
+// SPDX identifiers or class names. A denied license, or one missing from a
+// non-empty allow list, is a violation and limits the score as Action says.
+type Policy struct {
+	Allow    []string `mapstructure:"allow"`
+	Deny     []string `mapstructure:"deny"`
+	Action   string   `mapstructure:"action"`
+	MaxScore float64  `mapstructure:"max_score"`
+}
+
+func (p *Policy) Enabled() bool {
+	return len(p.Allow) > 0 || len(p.Deny) > 0
+}
+
+func (p *Policy) Validate() error {
+	switch p.Action {
+	case "", ActionZero:
+	case ActionCap:
+		if p.MaxScore < 0 || p.MaxScore > 100 {
+			return fmt.Errorf("license policy max_score must be between 0 and 100, got %v", p.MaxScore)
+		}
+	default:
+		return fmt.Errorf("unknown license policy action %q, expected %s or %s", p.Action, ActionZero, ActionCap)
+	}
+	return nil
+}
+
+// Check returns why spdxID violates the policy, or an empty string when it
+// does not.
+func (p *Policy) Check(spdxID string) string {
+	class := Classify(spdxID)
+	subject := "missing license"
+	if spdxID != "" {
+		subject = fmt.Sprintf("license %s (%s)", spdxID, class)
+	}
+
+	if matches(p.Deny, spdxID, class) {
+		return subject + " is denied"
+	}
+	if len(p.Allow) > 0 && !matches(p.Allow, spdxID, class) {
+		return subject + " is not allowed"
+	}
+	return ""
+}
+
+// Limit applies the policy action to the score of a violating repository.
+func (p *Policy) Limit(score float64) float64 {
+	if p.Action == ActionCap {
+		return min(score, p.MaxScore)
+	}
+	return 0
+}
+
+func matches(entries []string, spdxID string, class Class) bool {
+	for _, entry := range entries {
+		if strings.EqualFold(entry, string(class)) {
+			return true
+		}
+		// A bare identifier also covers its -only and -or-later variants.
+		if spdxID != "" && (strings.EqualFold(entry, spdxID) || strings.EqualFold(entry, baseID(spdxID))) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package license
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		spdxID string
+		want   Class
+	}{
+		{spdxID: "MIT", want: ClassPermissive},
+		{spdxID: "Apache-2.0", want: ClassPermissive},
+		{spdxID: "MPL-2.0", want: ClassWeakCopyleft},
+		{spdxID: "LGPL-2.1-or-later", want: ClassWeakCopyleft},
+		{spdxID: "GPL-3.0", want: ClassStrongCopyleft},
+		{spdxID: "AGPL-3.0-only", want: ClassStrongCopyleft},
+		{spdxID: NoAssertion, want: ClassUnknown},
+		{spdxID: "LicenseRef-custom", want: ClassUnknown},
+		{spdxID: "", want: ClassNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spdxID, func(t *testing.T) {
+			require.Equal(t, tt.want, Classify(tt.spdxID))
+		})
+	}
+}
+
+func TestPolicyCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		spdxID string
+		want   string
+	}{
+		{name: "no policy", policy: Policy{}, spdxID: "GPL-3.0", want: ""},
+		{name: "denied by id", policy: Policy{Deny: []string{"agpl-3.0"}}, spdxID: "AGPL-3.0-or-later", want: "license AGPL-3.0-or-later (strong-copyleft) is denied"},
+		{name: "variant does not cover other variants", policy: Policy{Deny: []string{"GPL-2.0-only"}}, spdxID: "GPL-2.0-or-later", want: ""},
+		{name: "denied by class", policy: Policy{Deny: []string{"strong-copyleft"}}, spdxID: "GPL-2.0", want: "license GPL-2.0 (strong-copyleft) is denied"},
+		{name: "allowed by class", policy: Policy{Allow: []string{"permissive"}}, spdxID: "MIT", want: ""},
+		{name: "not in allow list", policy: Policy{Allow: []string{"permissive", "MPL-2.0"}}, spdxID: NoAssertion, want: "license NOASSERTION (unknown) is not allowed"},
+		{name: "no license", policy: Policy{Allow: []string{"permissive"}}, spdxID: "", want: "missing license is not allowed"},
+		{name: "missing license denied", policy: Policy{Deny: []string{"none"}}, spdxID: "", want: "missing license is denied"},
+		{name: "deny wins over allow", policy: Policy{Allow: []string{"permissive"}, Deny: []string{"WTFPL"}}, spdxID: "WTFPL", want: "license WTFPL (permissive) is denied"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.policy.Check(tt.spdxID))
+		})
+	}
+}
+
+func TestPolicyLimit(t *testing.T) {
+	require.Equal(t, 0.0, (&Policy{}).Limit(80))
+	require.Equal(t, 0.0, (&Policy{Action: ActionZero}).Limit(80))
+	require.Equal(t, 50.0, (&Policy{Action: ActionCap, MaxScore: 50}).Limit(80))
+	require.Equal(t, 30.0, (&Policy{Action: ActionCap, MaxScore: 50}).Limit(30))
+}
+
+func TestPolicyValidate(t *testing.T) {
+	require.NoError(t, (&Policy{}).Validate())
+	require.NoError(t, (&Policy{Action: ActionCap, MaxScore: 40}).Validate())
+	require.ErrorContains(t, (&Policy{Action: ActionCap, MaxScore: 150}).Validate(), "max_score")
+	require.ErrorContains(t, (&Policy{Action: "warn"}).Validate(), "unknown license policy action")
+}
@@ -1,15 +1,55 @@
 package metrics
 
 const (
-	VarOwner = "owner"
-	VarName  = "name"
+	VarOwner  = "owner"
+	VarName   = "name"
+	VarSince  = "since"
+	VarCursor = "cursor"
+	VarQuery  = "query"
+	VarFirst  = "first"
 
-	CIGitHub   = ".github"
-	CIGitLab   = ".gitlab"
-	CICircleCI = ".circleci"
-	CITravis   = ".travis.yml"
-	CIJenkins  = "jenkinsfile"
+	CIGitLab      = ".gitlab-ci.yml"
+	CICircleCI    = ".circleci"
+	CITravis      = ".travis.yml"
+	CIJenkins     = "jenkinsfile"
+	CIAzure       = "azure-pipelines.yml"
+	CIDrone       = ".drone.yml"
+	CIBitbucket   = "bitbucket-pipelines.yml"
+	CIAppVeyor    = "appveyor.yml"
+	CIAppVeyorAlt = ".appveyor.yml"
+	CIBuildkite   = ".buildkite"
 
-	FileContributing    = "contributing.md"
-	FileContributingAlt = "contributing"
+	FileDependabot    = "dependabot.yml"
+	FileDependabotAlt = "dependabot.yaml"
+	DirCodeQL         = "codeql"
+
+	DirGitHub = ".github"
+	DirDocs   = "docs"
 )
+
+// Community health checks, as GitHub's community profile names them.
+const (
+	CommunityReadme        = "readme"
+	CommunityContributing  = "contributing"
+	CommunityCodeOfConduct = "code_of_conduct"
+	CommunitySecurity      = "security"
+	CommunityIssueTemplate = "issue_template"
+	CommunityPRTemplate    = "pull_request_template"
+	CommunityCodeowners    = "codeowners"
+	CommunityFunding       = "funding"
+	CommunityGovernance    = "governance"
+	CommunitySupport       = "support"
+)
+
+var CommunityChecks = []string{
+	CommunityReadme,
+	CommunityContributing,
+	CommunityCodeOfConduct,
+	CommunitySecurity,
+	CommunityIssueTemplate,
+	CommunityPRTemplate,
+	CommunityCodeowners,
+	CommunityFunding,
+	CommunityGovernance,
+	CommunitySupport,
+}
@@ -10,6 +10,22 @@ type Language struct {
 	Name githubv4.String
 }
 
+type LanguageEdge struct {
+	Size githubv4.Int
+	Node Language
+}
+
+type LanguagesConnection struct {
+	TotalSize githubv4.Int
+	Edges     []LanguageEdge
+}
+
+type TopicNode struct {
+	Topic struct {
+		Name githubv4.String
+	}
+}
+
 type IssuesConnection struct {
 	TotalCount githubv4.Int
 }
@@ -30,8 +46,35 @@ type CommitHistory struct {
 	Edges []CommitEdge
 }
 
+type CommitAuthorUser struct {
+	Login githubv4.String
+}
+
+type CommitAuthor struct {
+	Name  githubv4.String
+	Email githubv4.String
+	User  *CommitAuthorUser
+}
+
+type AuthoredCommit struct {
+	Author CommitAuthor
+}
+
+type PageInfo struct {
+	HasNextPage githubv4.Boolean
+	EndCursor   githubv4.String
+}
+
+// AuthorHistory is one page of default-branch commits with their authors.
+type AuthorHistory struct {
+	TotalCount githubv4.Int
+	PageInfo   PageInfo
+	Nodes      []AuthoredCommit
+}
+
 type Commit struct {
 	History CommitHistory `graphql:"history(first: 1)"`
+	Recent  AuthorHistory `graphql:"recent: history(first: 100, since: $since)"`
 }
 
 type GitObject struct {
@@ -39,11 +82,13 @@ type GitObject struct {
 }
 
 type Ref struct {
+	Name   githubv4.String
 	Target GitObject
 }
 
 type License struct {
-	Key githubv4.String
+	Key    githubv4.String
+	SpdxID githubv4.String `graphql:"spdxId"`
 }
 
 type TreeEntry struct {
@@ -58,8 +103,32 @@ type TreeObject struct {
 	Tree Tree `graphql:"... on Tree"`
 }
 
+type Blob struct {
+	Text *githubv4.String
+}
+
+type FileObject struct {
+	Blob Blob `graphql:"... on Blob"`
+}
+
+type FileEntry struct {
+	Name   githubv4.String
+	Object *FileObject
+}
+
+// FileTreeObject is a directory listing that includes the contents of its
+// files.
+type FileTreeObject struct {
+	Tree struct {
+		Entries []FileEntry
+	} `graphql:"... on Tree"`
+}
+
 type ReleaseNode struct {
-	PublishedAt githubv4.DateTime
+	PublishedAt  githubv4.DateTime
+	TagName      githubv4.String
+	IsPrerelease githubv4.Boolean
+	Description  githubv4.String
 }
 
 type ReleaseEdge struct {
@@ -71,25 +140,166 @@ type ReleasesConnection struct {
 	Edges      []ReleaseEdge
 }
 
+type Actor struct {
+	Login githubv4.String
+}
+
+type IssueComment struct {
+	Author            *Actor
+	AuthorAssociation githubv4.CommentAuthorAssociation
+	CreatedAt         githubv4.DateTime
+}
+
+type IssueNode struct {
+	Author    *Actor
+	CreatedAt githubv4.DateTime
+	ClosedAt  *githubv4.DateTime
+	Comments  struct {
+		Nodes []IssueComment
+	} `graphql:"comments(first: 5)"`
+}
+
+type IssueHistory struct {
+	Nodes []IssueNode
+}
+
+type PullRequestNode struct {
+	CreatedAt githubv4.DateTime
+	ClosedAt  *githubv4.DateTime
+	MergedAt  *githubv4.DateTime
+	Merged    githubv4.Boolean
+	Reviews   struct {
+		TotalCount githubv4.Int
+	} `graphql:"reviews(states: APPROVED)"`
+}
+
+type PullRequestHistory struct {
+	Nodes []PullRequestNode
+}
+
+type OpenPullRequestNode struct {
+	UpdatedAt githubv4.DateTime
+}
+
 type RepositoryGraphQL struct {
-	Owner            Owner
-	Name             githubv4.String
-	Description      githubv4.String
-	StargazerCount   githubv4.Int
-	ForkCount        githubv4.Int
-	IsArchived       githubv4.Boolean
+	Owner          Owner
+	Name           githubv4.String
+	Description    githubv4.String
+	StargazerCount githubv4.Int
+	ForkCount      githubv4.Int
+	IsArchived     githubv4.Boolean
+	IsFork         githubv4.Boolean
+	IsTemplate     githubv4.Boolean
+	Parent         *struct {
+		NameWithOwner githubv4.String
+	}
+	HomepageURL      githubv4.String `graphql:"homepageUrl"`
+	CreatedAt        githubv4.DateTime
+	DiskUsage        githubv4.Int
+	ViewerPermission githubv4.RepositoryPermission
+	RepositoryTopics struct {
+		Nodes []TopicNode
+	} `graphql:"repositoryTopics(first: 20)"`
 	PrimaryLanguage  *Language
+	Languages        LanguagesConnection    `graphql:"languages(first: 20, orderBy: {field: SIZE, direction: DESC})"`
 	Issues           IssuesConnection       `graphql:"issues(states: OPEN)"`
 	PullRequests     PullRequestsConnection `graphql:"pullRequests(states: OPEN)"`
 	DefaultBranchRef *Ref
 	LicenseInfo      *License
 	Object           TreeObject         `graphql:"object(expression: \"HEAD:\")"`
+	GitHubDir        TreeObject         `graphql:"githubDir: object(expression: \"HEAD:.github\")"`
+	Workflows        FileTreeObject     `graphql:"workflows: object(expression: \"HEAD:.github/workflows\")"`
+	DocsDir          TreeObject         `graphql:"docsDir: object(expression: \"HEAD:docs\")"`
 	Releases         ReleasesConnection `graphql:"releases(first: 10, orderBy: {field: CREATED_AT, direction: DESC})"`
 	Watchers         struct {
 		TotalCount githubv4.Int
 	}
+	RecentIssues IssueHistory `graphql:"recentIssues: issues(first: 50, orderBy: {field: CREATED_AT, direction: DESC})"`
+	// Closed and merged pull requests, most recently updated first.
+	RecentPullRequests PullRequestHistory `graphql:"recentPullRequests: pullRequests(states: [MERGED, CLOSED], first: 50, orderBy: {field: UPDATED_AT, direction: DESC})"`
+	// Open pull requests, least recently updated first.
+	IdlePullRequests struct {
+		Nodes []OpenPullRequestNode
+	} `graphql:"idlePullRequests: pullRequests(states: OPEN, first: 50, orderBy: {field: UPDATED_AT, direction: ASC})"`
+}
+
+type RateLimit struct {
+	Cost      githubv4.Int
+	Limit     githubv4.Int
+	Remaining githubv4.Int
+	ResetAt   githubv4.DateTime
 }
 
 type RepositoryQuery struct {
 	Repository RepositoryGraphQL `graphql:"repository(owner: $owner, name: $name)"`
+	RateLimit  RateLimit
+}
+
+// HistoryPageQuery continues the recent commit history of RepositoryGraphQL
+// past its first page.
+type HistoryPageQuery struct {
+	Repository struct {
+		DefaultBranchRef *struct {
+			Target struct {
+				Commit struct {
+					History AuthorHistory `graphql:"history(first: 100, since: $since, after: $cursor)"`
+				} `graphql:"... on Commit"`
+			}
+		}
+	} `graphql:"repository(owner: $owner, name: $name)"`
+	RateLimit RateLimit
+}
+
+// Per-repository estimates for RepositoryGraphQL, used to size batched queries:
+// the repository itself, releases(first: 10), history(first: 1),
+// history(first: 100), 20 topics, 20 languages, 50 recent issues with 5
+// comments each and 50 each of closed and open pull requests as nodes, and the
+// connections requested: issues, pullRequests, watchers, releases, topics,
+// languages, both histories, recent issues, one comments connection per issue,
+// both pull request lists and one reviews connection per closed pull request.
+// The issue and pull request pages are kept small because every repository of
+// a batch repeats them; TestRepositoryQueryCost recomputes both numbers.
+const (
+	RepositoryQueryNodes       = 552
+	RepositoryQueryConnections = 111
+)
+
+type OwnedRepositoryNode struct {
+	NameWithOwner    githubv4.String
+	IsArchived       githubv4.Boolean
+	IsFork           githubv4.Boolean
+	StargazerCount   githubv4.Int
+	PushedAt         *githubv4.DateTime
+	PrimaryLanguage  *Language
+	RepositoryTopics struct {
+		Nodes []TopicNode
+	} `graphql:"repositoryTopics(first: 20)"`
+}
+
+// OwnerRepositoriesQuery lists the repositories an organization or user owns,
+// most recently pushed first.
+type OwnerRepositoriesQuery struct {
+	RepositoryOwner *struct {
+		Typename     githubv4.String `graphql:"__typename"`
+		Repositories struct {
+			Nodes    []OwnedRepositoryNode
+			PageInfo PageInfo
+		} `graphql:"repositories(first: 100, after: $cursor, ownerAffiliations: OWNER, orderBy: {field: PUSHED_AT, direction: DESC})"`
+	} `graphql:"repositoryOwner(login: $owner)"`
+	RateLimit RateLimit
+}
+
+// SearchRepositoriesQuery runs a repository search, in GitHub's best-match
+// order unless the query sorts otherwise.
+type SearchRepositoriesQuery struct {
+	Search struct {
+		RepositoryCount githubv4.Int
+		Nodes           []struct {
+			Repository struct {
+				NameWithOwner githubv4.String
+			} `graphql:"... on Repository"`
+		}
+		PageInfo PageInfo
+	} `graphql:"search(query: $query, type: REPOSITORY, first: $first, after: $cursor)"`
+	RateLimit RateLimit
 }
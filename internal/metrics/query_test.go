@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	firstArgument = regexp.MustCompile(`\bfirst: (\d+)`)
+	queryPackage  = reflect.TypeOf(RepositoryGraphQL{}).PkgPath()
+)
+
+// queryCost counts the nodes and connections a query type requests the way
+// GitHub does: a connection counts once per parent item, and its page size
+// multiplies everything requested below its nodes or edges. Structs with
+// nodes, edges or a total count are connections.
+func queryCost(t reflect.Type, items int) (nodes, connections int) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldType := elemType(field.Type)
+		// Named types from other packages, such as githubv4.DateTime, are
+		// scalars.
+		if fieldType.Kind() != reflect.Struct || (fieldType.Name() != "" && fieldType.PkgPath() != queryPackage) {
+			continue
+		}
+
+		if !isConnection(fieldType) {
+			n, c := queryCost(fieldType, items)
+			nodes, connections = nodes+n, connections+c
+			continue
+		}
+
+		page := 0
+		if match := firstArgument.FindStringSubmatch(field.Tag.Get("graphql")); match != nil {
+			page, _ = strconv.Atoi(match[1])
+		}
+		connections += items
+		nodes += items * page
+		for _, name := range []string{"Nodes", "Edges"} {
+			if list, ok := fieldType.FieldByName(name); ok {
+				n, c := queryCost(elemType(list.Type), items*page)
+				nodes, connections = nodes+n, connections+c
+			}
+		}
+	}
+	return nodes, connections
+}
+
+func elemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t
+}
+
+func isConnection(t reflect.Type) bool {
+	for _, name := range []string{"Nodes", "Edges", "TotalCount"} {
+		if _, ok := t.FieldByName(name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRepositoryQueryCost(t *testing.T) {
+	nodes, connections := queryCost(reflect.TypeOf(RepositoryGraphQL{}), 1)
+	require.Equal(t, RepositoryQueryNodes, nodes+1, "nodes, counting the repository itself")
+	require.Equal(t, RepositoryQueryConnections, connections)
+}
@@ -8,6 +8,13 @@ type Recorder interface {
 	RecordRepositoryAnalysis(status string, duration time.Duration)
 	RecordCacheHit()
 	RecordCacheMiss()
+	RecordRateLimit(remaining, limit int, resetAt time.Time)
+	RecordRateLimitCost(cost int)
+	RecordRateLimitWait(duration time.Duration)
+	RecordRetry(reason string)
+	RecordTokenRequest(token string)
+	RecordTokenRateLimit(token string, remaining int)
+	RecordTokenAuthFailure(token string)
 }
 
 type NoOpRecorder struct{}
@@ -17,3 +24,10 @@ func (n NoOpRecorder) RecordHTTPDuration(method, endpoint string, duration time.
 func (n NoOpRecorder) RecordRepositoryAnalysis(status string, duration time.Duration)     {}
 func (n NoOpRecorder) RecordCacheHit()                                                    {}
 func (n NoOpRecorder) RecordCacheMiss()                                                   {}
+func (n NoOpRecorder) RecordRateLimit(remaining, limit int, resetAt time.Time)            {}
+func (n NoOpRecorder) RecordRateLimitCost(cost int)                                       {}
+func (n NoOpRecorder) RecordRateLimitWait(duration time.Duration)                         {}
+func (n NoOpRecorder) RecordRetry(reason string)                                          {}
+func (n NoOpRecorder) RecordTokenRequest(token string)                                    {}
+func (n NoOpRecorder) RecordTokenRateLimit(token string, remaining int)                   {}
+func (n NoOpRecorder) RecordTokenAuthFailure(token string)                                {}
@@ -2,44 +2,148 @@ package metrics
 
 import "time"
 
+type LanguageSize struct {
+	Name  string `json:"name" example:"Go"`
+	Bytes int    `json:"bytes" example:"1048576"`
+}
+
+// SBOMComponent identifies an SBOM entry: a CycloneDX component or an SPDX
+// package.
+type SBOMComponent struct {
+	Name    string `json:"name" example:"github.com/go-chi/chi/v5"`
+	Version string `json:"version,omitempty" example:"v5.2.2"`
+	// CycloneDX bom-ref or SPDX identifier
+	Ref string `json:"ref,omitempty" example:"pkg:golang/github.com/go-chi/chi/v5@v5.2.2"`
+}
+
 type Repository struct {
-	Owner            string
-	Name             string
-	Stars            int
-	Forks            int
-	OpenIssues       int
-	OpenPRs          int
-	LastCommitDate   time.Time
-	Description      string
-	PrimaryLanguage  string
-	IsArchived       bool
-	HasCICD          bool
-	HasLicense       bool
-	HasContributing  bool
-	ReleaseCount     int
-	LastReleaseDate  time.Time
-	HasReadme        bool
-	HasCodeOfConduct bool
-	HasSecurity      bool
-	Watchers         int
-	Score            float64
+	Host                  string
+	Owner                 string
+	Name                  string
+	MovedFrom             string
+	Stars                 int
+	Forks                 int
+	OpenIssues            int
+	OpenPRs               int
+	LastCommitDate        time.Time
+	WeeklyCommits         []int
+	Description           string
+	PrimaryLanguage       string
+	Languages             []LanguageSize
+	Topics                []string
+	HomepageURL           string
+	IsFork                bool
+	Parent                string
+	IsTemplate            bool
+	CreatedAt             time.Time
+	DiskUsageKB           int
+	IsArchived            bool
+	HasCICD               bool
+	CIProviders           []string
+	TestWorkflows         int
+	ReleaseWorkflows      int
+	BotWorkflows          int
+	HasDependabot         bool
+	HasRenovate           bool
+	HasCodeQL             bool
+	HasLicense            bool
+	LicenseSPDX           string
+	LicenseClass          string
+	LicenseViolation      string
+	HasContributing       bool
+	ReleaseCount          int
+	LastReleaseDate       time.Time
+	MedianReleaseInterval time.Duration
+	SemverReleaseShare    float64
+	PrereleaseShare       float64
+	ReleaseNotesShare     float64
+	RecentMajorBumps      int
+	HasReadme             bool
+	HasCodeOfConduct      bool
+	HasSecurity           bool
+	// Path of the file satisfying each community health check found, keyed
+	// by the Community* names.
+	CommunityFiles map[string]string
+	DefaultBranch  string
+	// Whether the token can administer the repository, which decides
+	// whether hidden settings read as disabled or unknown.
+	ViewerCanAdminister bool
+	// Nil when the token may not read the setting.
+	BranchProtected      *bool
+	RequiredReviews      int
+	RequiresStatusChecks bool
+	// Nil when the token may not read the setting.
+	VulnerabilityAlerts  *bool
+	SecurityAdvisories   int
+	Watchers             int
+	CommitCount          int
+	ContributorCount     int
+	TopContributorShare  float64
+	BusFactor            int
+	RecentIssueCount     int
+	MedianIssueResponse  time.Duration
+	MedianIssueCloseTime time.Duration
+	UnansweredIssueShare float64
+	RecentMergedPRs      int
+	RecentClosedPRs      int
+	MedianPRMergeTime    time.Duration
+	ApprovedMergeShare   float64
+	StalePRCount         int
+	Score                float64
+	// SBOM entries that resolved to this repository, when scoring an SBOM.
+	SBOMComponents []SBOMComponent
 }
 
-func (m *Repository) GetStars() int                 { return m.Stars }
-func (m *Repository) GetForks() int                 { return m.Forks }
-func (m *Repository) GetOpenIssues() int            { return m.OpenIssues }
-func (m *Repository) GetOpenPRs() int               { return m.OpenPRs }
-func (m *Repository) GetLastCommitDate() time.Time  { return m.LastCommitDate }
-func (m *Repository) GetIsArchived() bool           { return m.IsArchived }
-func (m *Repository) GetHasLicense() bool           { return m.HasLicense }
-func (m *Repository) GetHasCICD() bool              { return m.HasCICD }
-func (m *Repository) GetHasContributing() bool      { return m.HasContributing }
-func (m *Repository) GetReleaseCount() int          { return m.ReleaseCount }
-func (m *Repository) GetLastReleaseDate() time.Time { return m.LastReleaseDate }
-func (m *Repository) GetHasReadme() bool            { return m.HasReadme }
-func (m *Repository) GetHasCodeOfConduct() bool     { return m.HasCodeOfConduct }
-func (m *Repository) GetHasSecurity() bool          { return m.HasSecurity }
-func (m *Repository) GetWatchers() int              { return m.Watchers }
+func (m *Repository) GetStars() int                           { return m.Stars }
+func (m *Repository) GetForks() int                           { return m.Forks }
+func (m *Repository) GetOpenIssues() int                      { return m.OpenIssues }
+func (m *Repository) GetOpenPRs() int                         { return m.OpenPRs }
+func (m *Repository) GetLastCommitDate() time.Time            { return m.LastCommitDate }
+func (m *Repository) GetWeeklyCommits() []int                 { return m.WeeklyCommits }
+func (m *Repository) GetIsArchived() bool                     { return m.IsArchived }
+func (m *Repository) GetHasLicense() bool                     { return m.HasLicense }
+func (m *Repository) GetHasCICD() bool                        { return m.HasCICD }
+func (m *Repository) GetHasContributing() bool                { return m.HasContributing }
+func (m *Repository) GetReleaseCount() int                    { return m.ReleaseCount }
+func (m *Repository) GetMedianReleaseInterval() time.Duration { return m.MedianReleaseInterval }
+func (m *Repository) GetSemverReleaseShare() float64          { return m.SemverReleaseShare }
+func (m *Repository) GetPrereleaseShare() float64             { return m.PrereleaseShare }
+func (m *Repository) GetReleaseNotesShare() float64           { return m.ReleaseNotesShare }
+func (m *Repository) GetRecentMajorBumps() int                { return m.RecentMajorBumps }
+func (m *Repository) GetLastReleaseDate() time.Time           { return m.LastReleaseDate }
+func (m *Repository) GetHasReadme() bool                      { return m.HasReadme }
+func (m *Repository) GetHasCodeOfConduct() bool               { return m.HasCodeOfConduct }
+func (m *Repository) GetHasSecurity() bool                    { return m.HasSecurity }
+func (m *Repository) GetBranchProtected() *bool               { return m.BranchProtected }
+func (m *Repository) GetRequiredReviews() int                 { return m.RequiredReviews }
+func (m *Repository) GetRequiresStatusChecks() bool           { return m.RequiresStatusChecks }
+func (m *Repository) GetVulnerabilityAlerts() *bool           { return m.VulnerabilityAlerts }
+func (m *Repository) GetSecurityAdvisories() int              { return m.SecurityAdvisories }
+func (m *Repository) GetTopics() []string                     { return m.Topics }
+func (m *Repository) GetHomepageURL() string                  { return m.HomepageURL }
+func (m *Repository) GetCreatedAt() time.Time                 { return m.CreatedAt }
+func (m *Repository) GetWatchers() int                        { return m.Watchers }
+func (m *Repository) GetContributorCount() int                { return m.ContributorCount }
+func (m *Repository) GetTopContributorShare() float64         { return m.TopContributorShare }
+func (m *Repository) GetBusFactor() int                       { return m.BusFactor }
+func (m *Repository) GetRecentIssueCount() int                { return m.RecentIssueCount }
+func (m *Repository) GetMedianIssueResponse() time.Duration   { return m.MedianIssueResponse }
+func (m *Repository) GetMedianIssueCloseTime() time.Duration  { return m.MedianIssueCloseTime }
+func (m *Repository) GetUnansweredIssueShare() float64        { return m.UnansweredIssueShare }
+func (m *Repository) GetRecentMergedPRs() int                 { return m.RecentMergedPRs }
+func (m *Repository) GetRecentClosedPRs() int                 { return m.RecentClosedPRs }
+func (m *Repository) GetMedianPRMergeTime() time.Duration     { return m.MedianPRMergeTime }
+func (m *Repository) GetApprovedMergeShare() float64          { return m.ApprovedMergeShare }
+func (m *Repository) GetStalePRCount() int                    { return m.StalePRCount }
+
+// MergeRatio returns the share of recently closed pull requests that were
+// merged rather than closed unmerged.
+func (m *Repository) MergeRatio() float64 {
+	if m.RecentMergedPRs+m.RecentClosedPRs == 0 {
+		return 0
+	}
+	return float64(m.RecentMergedPRs) / float64(m.RecentMergedPRs+m.RecentClosedPRs)
+}
 
 func (m *Repository) DaysSinceLastCommit() int {
 	if m.LastCommitDate.IsZero() {
@@ -48,6 +152,18 @@ func (m *Repository) DaysSinceLastCommit() int {
 	return int(time.Since(m.LastCommitDate).Hours() / 24)
 }
 
+// AgeDays returns how many days ago the repository was created, or -1 when
+// the creation date is unknown.
+func (m *Repository) AgeDays() int {
+	if m.CreatedAt.IsZero() {
+		return -1
+	}
+	return int(time.Since(m.CreatedAt).Hours() / 24)
+}
+
 func (m *Repository) FullName() string {
+	if m.Host != "" {
+		return m.Host + "/" + m.Owner + "/" + m.Name
+	}
 	return m.Owner + "/" + m.Name
 }
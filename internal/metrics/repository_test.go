@@ -121,6 +121,7 @@ func TestDaysSinceLastCommit(t *testing.T) {
 func TestFullName(t *testing.T) {
 	tests := []struct {
 		name     string
+		host     string
 		owner    string
 		repoName string
 		want     string
@@ -137,6 +138,13 @@ func TestFullName(t *testing.T) {
 			repoName: "kubernetes",
 			want:     "kubernetes/kubernetes",
 		},
+		{
+			name:     "enterprise host",
+			host:     "ghe.corp.local",
+			owner:    "team",
+			repoName: "svc",
+			want:     "ghe.corp.local/team/svc",
+		},
 		{
 			name:     "empty owner",
 			owner:    "",
@@ -154,6 +162,7 @@ func TestFullName(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := &Repository{
+				Host:  tt.host,
 				Owner: tt.owner,
 				Name:  tt.repoName,
 			}
@@ -162,3 +171,23 @@ func TestFullName(t *testing.T) {
 		})
 	}
 }
+
+func TestRepositoryMergeRatio(t *testing.T) {
+	tests := []struct {
+		name   string
+		merged int
+		closed int
+		want   float64
+	}{
+		{name: "no pull requests", merged: 0, closed: 0, want: 0},
+		{name: "all merged", merged: 4, closed: 0, want: 1},
+		{name: "mixed", merged: 3, closed: 1, want: 0.75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &Repository{RecentMergedPRs: tt.merged, RecentClosedPRs: tt.closed}
+			require.Equal(t, tt.want, repo.MergeRatio())
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	formatter "github.com/kdimtriCP/gh-inspector/internal/formatter"
 	metrics "github.com/kdimtriCP/gh-inspector/internal/metrics"
 )
 
@@ -48,3 +49,17 @@ func (mr *MockFormatterMockRecorder) Format(writer, metrics interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Format", reflect.TypeOf((*MockFormatter)(nil).Format), writer, metrics)
 }
+
+// FormatErrors mocks base method.
+func (m *MockFormatter) FormatErrors(writer io.Writer, errors []*formatter.ErrorRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FormatErrors", writer, errors)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FormatErrors indicates an expected call of FormatErrors.
+func (mr *MockFormatterMockRecorder) FormatErrors(writer, errors interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FormatErrors", reflect.TypeOf((*MockFormatter)(nil).FormatErrors), writer, errors)
+}
@@ -50,6 +50,44 @@ func (mr *MockMetricsCollectorMockRecorder) CollectBasicMetrics(ctx, repoFullNam
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectBasicMetrics", reflect.TypeOf((*MockMetricsCollector)(nil).CollectBasicMetrics), ctx, repoFullName)
 }
 
+// MockBatchMetricsCollector is a mock of BatchMetricsCollector interface.
+type MockBatchMetricsCollector struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchMetricsCollectorMockRecorder
+}
+
+// MockBatchMetricsCollectorMockRecorder is the mock recorder for MockBatchMetricsCollector.
+type MockBatchMetricsCollectorMockRecorder struct {
+	mock *MockBatchMetricsCollector
+}
+
+// NewMockBatchMetricsCollector creates a new mock instance.
+func NewMockBatchMetricsCollector(ctrl *gomock.Controller) *MockBatchMetricsCollector {
+	mock := &MockBatchMetricsCollector{ctrl: ctrl}
+	mock.recorder = &MockBatchMetricsCollectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchMetricsCollector) EXPECT() *MockBatchMetricsCollectorMockRecorder {
+	return m.recorder
+}
+
+// CollectBatchMetrics mocks base method.
+func (m *MockBatchMetricsCollector) CollectBatchMetrics(ctx context.Context, repoFullNames []string) ([]*metrics.Repository, []error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectBatchMetrics", ctx, repoFullNames)
+	ret0, _ := ret[0].([]*metrics.Repository)
+	ret1, _ := ret[1].([]error)
+	return ret0, ret1
+}
+
+// CollectBatchMetrics indicates an expected call of CollectBatchMetrics.
+func (mr *MockBatchMetricsCollectorMockRecorder) CollectBatchMetrics(ctx, repoFullNames interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectBatchMetrics", reflect.TypeOf((*MockBatchMetricsCollector)(nil).CollectBatchMetrics), ctx, repoFullNames)
+}
+
 // MockAnalyzer is a mock of Analyzer interface.
 type MockAnalyzer struct {
 	ctrl     *gomock.Controller
@@ -87,3 +125,108 @@ func (mr *MockAnalyzerMockRecorder) Analyze(ctx, repo interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Analyze", reflect.TypeOf((*MockAnalyzer)(nil).Analyze), ctx, repo)
 }
+
+// MockBatchAnalyzer is a mock of BatchAnalyzer interface.
+type MockBatchAnalyzer struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchAnalyzerMockRecorder
+}
+
+// MockBatchAnalyzerMockRecorder is the mock recorder for MockBatchAnalyzer.
+type MockBatchAnalyzerMockRecorder struct {
+	mock *MockBatchAnalyzer
+}
+
+// NewMockBatchAnalyzer creates a new mock instance.
+func NewMockBatchAnalyzer(ctrl *gomock.Controller) *MockBatchAnalyzer {
+	mock := &MockBatchAnalyzer{ctrl: ctrl}
+	mock.recorder = &MockBatchAnalyzerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchAnalyzer) EXPECT() *MockBatchAnalyzerMockRecorder {
+	return m.recorder
+}
+
+// Analyze mocks base method.
+func (m *MockBatchAnalyzer) Analyze(ctx context.Context, repo string) (*metrics.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Analyze", ctx, repo)
+	ret0, _ := ret[0].(*metrics.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Analyze indicates an expected call of Analyze.
+func (mr *MockBatchAnalyzerMockRecorder) Analyze(ctx, repo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Analyze", reflect.TypeOf((*MockBatchAnalyzer)(nil).Analyze), ctx, repo)
+}
+
+// AnalyzeBatch mocks base method.
+func (m *MockBatchAnalyzer) AnalyzeBatch(ctx context.Context, repos []string) ([]*metrics.Repository, []error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnalyzeBatch", ctx, repos)
+	ret0, _ := ret[0].([]*metrics.Repository)
+	ret1, _ := ret[1].([]error)
+	return ret0, ret1
+}
+
+// AnalyzeBatch indicates an expected call of AnalyzeBatch.
+func (mr *MockBatchAnalyzerMockRecorder) AnalyzeBatch(ctx, repos interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnalyzeBatch", reflect.TypeOf((*MockBatchAnalyzer)(nil).AnalyzeBatch), ctx, repos)
+}
+
+// BatchSize mocks base method.
+func (m *MockBatchAnalyzer) BatchSize() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchSize")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// BatchSize indicates an expected call of BatchSize.
+func (mr *MockBatchAnalyzerMockRecorder) BatchSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchSize", reflect.TypeOf((*MockBatchAnalyzer)(nil).BatchSize))
+}
+
+// MockSearcher is a mock of Searcher interface.
+type MockSearcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearcherMockRecorder
+}
+
+// MockSearcherMockRecorder is the mock recorder for MockSearcher.
+type MockSearcherMockRecorder struct {
+	mock *MockSearcher
+}
+
+// NewMockSearcher creates a new mock instance.
+func NewMockSearcher(ctrl *gomock.Controller) *MockSearcher {
+	mock := &MockSearcher{ctrl: ctrl}
+	mock.recorder = &MockSearcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearcher) EXPECT() *MockSearcherMockRecorder {
+	return m.recorder
+}
+
+// SearchRepositories mocks base method.
+func (m *MockSearcher) SearchRepositories(ctx context.Context, query string, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchRepositories", ctx, query, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchRepositories indicates an expected call of SearchRepositories.
+func (mr *MockSearcherMockRecorder) SearchRepositories(ctx, query, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchRepositories", reflect.TypeOf((*MockSearcher)(nil).SearchRepositories), ctx, query, limit)
+}
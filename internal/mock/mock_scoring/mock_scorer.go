@@ -34,6 +34,76 @@ func (m *MockRepositoryMetrics) EXPECT() *MockRepositoryMetricsMockRecorder {
 	return m.recorder
 }
 
+// GetApprovedMergeShare mocks base method.
+func (m *MockRepositoryMetrics) GetApprovedMergeShare() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApprovedMergeShare")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetApprovedMergeShare indicates an expected call of GetApprovedMergeShare.
+func (mr *MockRepositoryMetricsMockRecorder) GetApprovedMergeShare() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovedMergeShare", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetApprovedMergeShare))
+}
+
+// GetBranchProtected mocks base method.
+func (m *MockRepositoryMetrics) GetBranchProtected() *bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranchProtected")
+	ret0, _ := ret[0].(*bool)
+	return ret0
+}
+
+// GetBranchProtected indicates an expected call of GetBranchProtected.
+func (mr *MockRepositoryMetricsMockRecorder) GetBranchProtected() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranchProtected", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetBranchProtected))
+}
+
+// GetBusFactor mocks base method.
+func (m *MockRepositoryMetrics) GetBusFactor() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBusFactor")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetBusFactor indicates an expected call of GetBusFactor.
+func (mr *MockRepositoryMetricsMockRecorder) GetBusFactor() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBusFactor", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetBusFactor))
+}
+
+// GetContributorCount mocks base method.
+func (m *MockRepositoryMetrics) GetContributorCount() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContributorCount")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetContributorCount indicates an expected call of GetContributorCount.
+func (mr *MockRepositoryMetricsMockRecorder) GetContributorCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContributorCount", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetContributorCount))
+}
+
+// GetCreatedAt mocks base method.
+func (m *MockRepositoryMetrics) GetCreatedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCreatedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// GetCreatedAt indicates an expected call of GetCreatedAt.
+func (mr *MockRepositoryMetricsMockRecorder) GetCreatedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreatedAt", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetCreatedAt))
+}
+
 // GetForks mocks base method.
 func (m *MockRepositoryMetrics) GetForks() int {
 	m.ctrl.T.Helper()
@@ -132,6 +202,20 @@ func (mr *MockRepositoryMetricsMockRecorder) GetHasSecurity() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHasSecurity", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetHasSecurity))
 }
 
+// GetHomepageURL mocks base method.
+func (m *MockRepositoryMetrics) GetHomepageURL() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHomepageURL")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetHomepageURL indicates an expected call of GetHomepageURL.
+func (mr *MockRepositoryMetricsMockRecorder) GetHomepageURL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHomepageURL", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetHomepageURL))
+}
+
 // GetIsArchived mocks base method.
 func (m *MockRepositoryMetrics) GetIsArchived() bool {
 	m.ctrl.T.Helper()
@@ -174,6 +258,62 @@ func (mr *MockRepositoryMetricsMockRecorder) GetLastReleaseDate() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastReleaseDate", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetLastReleaseDate))
 }
 
+// GetMedianIssueCloseTime mocks base method.
+func (m *MockRepositoryMetrics) GetMedianIssueCloseTime() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMedianIssueCloseTime")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetMedianIssueCloseTime indicates an expected call of GetMedianIssueCloseTime.
+func (mr *MockRepositoryMetricsMockRecorder) GetMedianIssueCloseTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedianIssueCloseTime", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetMedianIssueCloseTime))
+}
+
+// GetMedianIssueResponse mocks base method.
+func (m *MockRepositoryMetrics) GetMedianIssueResponse() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMedianIssueResponse")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetMedianIssueResponse indicates an expected call of GetMedianIssueResponse.
+func (mr *MockRepositoryMetricsMockRecorder) GetMedianIssueResponse() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedianIssueResponse", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetMedianIssueResponse))
+}
+
+// GetMedianPRMergeTime mocks base method.
+func (m *MockRepositoryMetrics) GetMedianPRMergeTime() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMedianPRMergeTime")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetMedianPRMergeTime indicates an expected call of GetMedianPRMergeTime.
+func (mr *MockRepositoryMetricsMockRecorder) GetMedianPRMergeTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedianPRMergeTime", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetMedianPRMergeTime))
+}
+
+// GetMedianReleaseInterval mocks base method.
+func (m *MockRepositoryMetrics) GetMedianReleaseInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMedianReleaseInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetMedianReleaseInterval indicates an expected call of GetMedianReleaseInterval.
+func (mr *MockRepositoryMetricsMockRecorder) GetMedianReleaseInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedianReleaseInterval", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetMedianReleaseInterval))
+}
+
 // GetOpenIssues mocks base method.
 func (m *MockRepositoryMetrics) GetOpenIssues() int {
 	m.ctrl.T.Helper()
@@ -202,6 +342,76 @@ func (mr *MockRepositoryMetricsMockRecorder) GetOpenPRs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenPRs", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetOpenPRs))
 }
 
+// GetPrereleaseShare mocks base method.
+func (m *MockRepositoryMetrics) GetPrereleaseShare() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrereleaseShare")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetPrereleaseShare indicates an expected call of GetPrereleaseShare.
+func (mr *MockRepositoryMetricsMockRecorder) GetPrereleaseShare() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrereleaseShare", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetPrereleaseShare))
+}
+
+// GetRecentClosedPRs mocks base method.
+func (m *MockRepositoryMetrics) GetRecentClosedPRs() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentClosedPRs")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetRecentClosedPRs indicates an expected call of GetRecentClosedPRs.
+func (mr *MockRepositoryMetricsMockRecorder) GetRecentClosedPRs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentClosedPRs", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetRecentClosedPRs))
+}
+
+// GetRecentIssueCount mocks base method.
+func (m *MockRepositoryMetrics) GetRecentIssueCount() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentIssueCount")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetRecentIssueCount indicates an expected call of GetRecentIssueCount.
+func (mr *MockRepositoryMetricsMockRecorder) GetRecentIssueCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentIssueCount", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetRecentIssueCount))
+}
+
+// GetRecentMajorBumps mocks base method.
+func (m *MockRepositoryMetrics) GetRecentMajorBumps() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentMajorBumps")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetRecentMajorBumps indicates an expected call of GetRecentMajorBumps.
+func (mr *MockRepositoryMetricsMockRecorder) GetRecentMajorBumps() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentMajorBumps", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetRecentMajorBumps))
+}
+
+// GetRecentMergedPRs mocks base method.
+func (m *MockRepositoryMetrics) GetRecentMergedPRs() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentMergedPRs")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetRecentMergedPRs indicates an expected call of GetRecentMergedPRs.
+func (mr *MockRepositoryMetricsMockRecorder) GetRecentMergedPRs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentMergedPRs", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetRecentMergedPRs))
+}
+
 // GetReleaseCount mocks base method.
 func (m *MockRepositoryMetrics) GetReleaseCount() int {
 	m.ctrl.T.Helper()
@@ -216,6 +426,90 @@ func (mr *MockRepositoryMetricsMockRecorder) GetReleaseCount() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReleaseCount", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetReleaseCount))
 }
 
+// GetReleaseNotesShare mocks base method.
+func (m *MockRepositoryMetrics) GetReleaseNotesShare() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReleaseNotesShare")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetReleaseNotesShare indicates an expected call of GetReleaseNotesShare.
+func (mr *MockRepositoryMetricsMockRecorder) GetReleaseNotesShare() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReleaseNotesShare", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetReleaseNotesShare))
+}
+
+// GetRequiredReviews mocks base method.
+func (m *MockRepositoryMetrics) GetRequiredReviews() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRequiredReviews")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetRequiredReviews indicates an expected call of GetRequiredReviews.
+func (mr *MockRepositoryMetricsMockRecorder) GetRequiredReviews() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRequiredReviews", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetRequiredReviews))
+}
+
+// GetRequiresStatusChecks mocks base method.
+func (m *MockRepositoryMetrics) GetRequiresStatusChecks() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRequiresStatusChecks")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// GetRequiresStatusChecks indicates an expected call of GetRequiresStatusChecks.
+func (mr *MockRepositoryMetricsMockRecorder) GetRequiresStatusChecks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRequiresStatusChecks", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetRequiresStatusChecks))
+}
+
+// GetSecurityAdvisories mocks base method.
+func (m *MockRepositoryMetrics) GetSecurityAdvisories() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecurityAdvisories")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetSecurityAdvisories indicates an expected call of GetSecurityAdvisories.
+func (mr *MockRepositoryMetricsMockRecorder) GetSecurityAdvisories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityAdvisories", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetSecurityAdvisories))
+}
+
+// GetSemverReleaseShare mocks base method.
+func (m *MockRepositoryMetrics) GetSemverReleaseShare() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSemverReleaseShare")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetSemverReleaseShare indicates an expected call of GetSemverReleaseShare.
+func (mr *MockRepositoryMetricsMockRecorder) GetSemverReleaseShare() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSemverReleaseShare", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetSemverReleaseShare))
+}
+
+// GetStalePRCount mocks base method.
+func (m *MockRepositoryMetrics) GetStalePRCount() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStalePRCount")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetStalePRCount indicates an expected call of GetStalePRCount.
+func (mr *MockRepositoryMetricsMockRecorder) GetStalePRCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStalePRCount", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetStalePRCount))
+}
+
 // GetStars mocks base method.
 func (m *MockRepositoryMetrics) GetStars() int {
 	m.ctrl.T.Helper()
@@ -230,6 +524,62 @@ func (mr *MockRepositoryMetricsMockRecorder) GetStars() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStars", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetStars))
 }
 
+// GetTopContributorShare mocks base method.
+func (m *MockRepositoryMetrics) GetTopContributorShare() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopContributorShare")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetTopContributorShare indicates an expected call of GetTopContributorShare.
+func (mr *MockRepositoryMetricsMockRecorder) GetTopContributorShare() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopContributorShare", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetTopContributorShare))
+}
+
+// GetTopics mocks base method.
+func (m *MockRepositoryMetrics) GetTopics() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopics")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetTopics indicates an expected call of GetTopics.
+func (mr *MockRepositoryMetricsMockRecorder) GetTopics() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopics", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetTopics))
+}
+
+// GetUnansweredIssueShare mocks base method.
+func (m *MockRepositoryMetrics) GetUnansweredIssueShare() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnansweredIssueShare")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetUnansweredIssueShare indicates an expected call of GetUnansweredIssueShare.
+func (mr *MockRepositoryMetricsMockRecorder) GetUnansweredIssueShare() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnansweredIssueShare", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetUnansweredIssueShare))
+}
+
+// GetVulnerabilityAlerts mocks base method.
+func (m *MockRepositoryMetrics) GetVulnerabilityAlerts() *bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVulnerabilityAlerts")
+	ret0, _ := ret[0].(*bool)
+	return ret0
+}
+
+// GetVulnerabilityAlerts indicates an expected call of GetVulnerabilityAlerts.
+func (mr *MockRepositoryMetricsMockRecorder) GetVulnerabilityAlerts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVulnerabilityAlerts", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetVulnerabilityAlerts))
+}
+
 // GetWatchers mocks base method.
 func (m *MockRepositoryMetrics) GetWatchers() int {
 	m.ctrl.T.Helper()
@@ -243,3 +593,17 @@ func (mr *MockRepositoryMetricsMockRecorder) GetWatchers() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchers", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetWatchers))
 }
+
+// GetWeeklyCommits mocks base method.
+func (m *MockRepositoryMetrics) GetWeeklyCommits() []int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWeeklyCommits")
+	ret0, _ := ret[0].([]int)
+	return ret0
+}
+
+// GetWeeklyCommits indicates an expected call of GetWeeklyCommits.
+func (mr *MockRepositoryMetricsMockRecorder) GetWeeklyCommits() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWeeklyCommits", reflect.TypeOf((*MockRepositoryMetrics)(nil).GetWeeklyCommits))
+}
@@ -0,0 +1,246 @@
+package repolist
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatAuto Format = "auto"
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+
+	// Stdin is the file name that reads the list from standard input.
+	Stdin = "-"
+)
+
+// defaultColumns are the CSV headers and JSON object keys tried when no
+// column is chosen. They cover gh-inspector's own CSV output, `gh repo list
+// --json nameWithOwner` and the GitHub REST API.
+var defaultColumns = []string{"repository", "repo", "nameWithOwner", "full_name", "url"}
+
+// Options says how to read a repository list. Column picks the CSV column or
+// JSON object key holding the repository, by header name or 1-based index.
+type Options struct {
+	Format Format
+	Column string
+}
+
+// ReadFile reads the repository list in path, or from stdin when path is "-".
+// With FormatAuto the format follows the file extension, and a list that
+// starts with '[' is JSON.
+func ReadFile(path string, stdin io.Reader, opts Options) ([]string, error) {
+	var data []byte
+	var err error
+	if path == Stdin {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read repository list %s: %w", path, err)
+	}
+
+	format := opts.Format
+	if format == "" || format == FormatAuto {
+		format = detectFormat(path, data)
+	}
+	opts.Format = format
+
+	repos, err := Parse(data, opts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository list %s: %w", path, err)
+	}
+	return repos, nil
+}
+
+func detectFormat(path string, data []byte) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON
+	case ".csv":
+		return FormatCSV
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		return FormatJSON
+	}
+	return FormatText
+}
+
+// Parse reads a repository list in the given format.
+func Parse(data []byte, opts Options) ([]string, error) {
+	switch opts.Format {
+	case FormatText, FormatAuto, "":
+		return parseText(data)
+	case FormatJSON:
+		return parseJSON(data, opts.Column)
+	case FormatCSV:
+		return parseCSV(data, opts.Column)
+	default:
+		return nil, fmt.Errorf("unsupported format %q, expected text, json or csv", opts.Format)
+	}
+}
+
+// parseText reads one repository per line. Blank lines and everything after
+// a '#' are ignored.
+func parseText(data []byte) ([]string, error) {
+	var repos []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			repos = append(repos, line)
+		}
+	}
+	return repos, scanner.Err()
+}
+
+// parseJSON reads an array of repository strings, or of objects holding the
+// repository under column.
+func parseJSON(data []byte, column string) ([]string, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("expected a JSON array: %w", err)
+	}
+
+	var repos []string
+	for i, item := range items {
+		var repo string
+		if err := json.Unmarshal(item, &repo); err == nil {
+			repos = append(repos, repo)
+			continue
+		}
+
+		var object map[string]interface{}
+		if err := json.Unmarshal(item, &object); err != nil {
+			return nil, fmt.Errorf("item %d is neither a string nor an object", i+1)
+		}
+		key, ok := jsonKey(object, column)
+		if !ok {
+			return nil, fmt.Errorf("item %d has no %s field", i+1, columnDescription(column))
+		}
+		repo, ok = object[key].(string)
+		if !ok {
+			return nil, fmt.Errorf("item %d: field %s is not a string", i+1, key)
+		}
+		repos = append(repos, repo)
+	}
+	return repos, nil
+}
+
+func jsonKey(object map[string]interface{}, column string) (string, bool) {
+	candidates := defaultColumns
+	if column != "" {
+		candidates = []string{column}
+	}
+	for _, candidate := range candidates {
+		for key := range object {
+			if strings.EqualFold(key, candidate) {
+				return key, true
+			}
+		}
+	}
+	return "", false
+}
+
+// parseCSV reads the repository column of a CSV list. A column chosen by name
+// requires a header row. The first row is the header when it names a known
+// column, or otherwise when none of its cells looks like owner/name; headers
+// such as "CI/CD" would fail the latter.
+func parseCSV(data []byte, column string) ([]string, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	hasHeader := isHeader(header, column)
+
+	index := -1
+	if n, err := strconv.Atoi(column); err == nil {
+		if n < 1 {
+			return nil, fmt.Errorf("column %d is out of range, columns start at 1", n)
+		}
+		index = n - 1
+	} else if hasHeader {
+		candidates := defaultColumns
+		if column != "" {
+			candidates = []string{column}
+		}
+	search:
+		for _, candidate := range candidates {
+			for i, name := range header {
+				if strings.EqualFold(strings.TrimSpace(name), candidate) {
+					index = i
+					break search
+				}
+			}
+		}
+	}
+	if index < 0 {
+		if column != "" || len(header) > 1 {
+			return nil, fmt.Errorf("no %s column", columnDescription(column))
+		}
+		index = 0
+	}
+
+	if hasHeader {
+		records = records[1:]
+	}
+	var repos []string
+	for _, record := range records {
+		if index >= len(record) {
+			continue
+		}
+		if repo := strings.TrimSpace(record[index]); repo != "" {
+			repos = append(repos, repo)
+		}
+	}
+	return repos, nil
+}
+
+func isHeader(row []string, column string) bool {
+	names := defaultColumns
+	if _, err := strconv.Atoi(column); err != nil && column != "" {
+		names = append([]string{column}, defaultColumns...)
+	}
+	for _, cell := range row {
+		for _, name := range names {
+			if strings.EqualFold(strings.TrimSpace(cell), name) {
+				return true
+			}
+		}
+	}
+	for _, cell := range row {
+		if strings.Contains(cell, "/") {
+			return false
+		}
+	}
+	return true
+}
+
+func columnDescription(column string) string {
+	if column != "" {
+		return strconv.Quote(column)
+	}
+	last := len(defaultColumns) - 1
+	return strings.Join(defaultColumns[:last], ", ") + " or " + defaultColumns[last]
+}
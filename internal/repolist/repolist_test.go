@@ -0,0 +1,166 @@
+package repolist
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdimtriCP/gh-inspector/internal/formatter"
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		opts    Options
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "text with comments",
+			data: "# web frameworks\ngo-chi/chi\n\n  labstack/echo  # router\nhttps://github.com/gin-gonic/gin\n",
+			opts: Options{Format: FormatText},
+			want: []string{"go-chi/chi", "labstack/echo", "https://github.com/gin-gonic/gin"},
+		},
+		{
+			name: "json strings",
+			data: `["go-chi/chi", "labstack/echo"]`,
+			opts: Options{Format: FormatJSON},
+			want: []string{"go-chi/chi", "labstack/echo"},
+		},
+		{
+			name: "json objects from gh repo list",
+			data: `[{"nameWithOwner": "go-chi/chi"}, {"nameWithOwner": "labstack/echo"}]`,
+			opts: Options{Format: FormatJSON},
+			want: []string{"go-chi/chi", "labstack/echo"},
+		},
+		{
+			name: "json objects with a chosen key",
+			data: `[{"name": "chi", "source": "go-chi/chi"}]`,
+			opts: Options{Format: FormatJSON, Column: "source"},
+			want: []string{"go-chi/chi"},
+		},
+		{
+			name:    "json object without the key",
+			data:    `["go-chi/chi", {"name": "echo"}]`,
+			opts:    Options{Format: FormatJSON},
+			wantErr: "item 2 has no repository, repo, nameWithOwner, full_name or url field",
+		},
+		{
+			name:    "json not an array",
+			data:    `{"repositories": []}`,
+			opts:    Options{Format: FormatJSON},
+			wantErr: "expected a JSON array",
+		},
+		{
+			name: "csv with default header",
+			data: "Repository,Score\ngo-chi/chi,85.5\n# skipped\nlabstack/echo,80\n",
+			opts: Options{Format: FormatCSV},
+			want: []string{"go-chi/chi", "labstack/echo"},
+		},
+		{
+			name: "csv header with a slash",
+			data: "Repository,CI/CD,Score\ngo-chi/chi,Yes,85.5\n",
+			opts: Options{Format: FormatCSV},
+			want: []string{"go-chi/chi"},
+		},
+		{
+			name: "csv column by name",
+			data: "team,source\nweb,go-chi/chi\napi,\"labstack/echo\"\n",
+			opts: Options{Format: FormatCSV, Column: "Source"},
+			want: []string{"go-chi/chi", "labstack/echo"},
+		},
+		{
+			name: "csv column by index without header",
+			data: "web,go-chi/chi\napi,labstack/echo\n",
+			opts: Options{Format: FormatCSV, Column: "2"},
+			want: []string{"go-chi/chi", "labstack/echo"},
+		},
+		{
+			name: "csv single column without header",
+			data: "go-chi/chi\nlabstack/echo\n",
+			opts: Options{Format: FormatCSV},
+			want: []string{"go-chi/chi", "labstack/echo"},
+		},
+		{
+			name:    "csv missing column",
+			data:    "team,source\nweb,go-chi/chi\n",
+			opts:    Options{Format: FormatCSV, Column: "repo_url"},
+			wantErr: `no "repo_url" column`,
+		},
+		{
+			name:    "unsupported format",
+			data:    "go-chi/chi",
+			opts:    Options{Format: "yaml"},
+			wantErr: `unsupported format "yaml"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.data), tt.opts)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("format from extension", func(t *testing.T) {
+		repos, err := ReadFile(write("deps.csv", "repository\ngo-chi/chi\n"), nil, Options{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"go-chi/chi"}, repos)
+	})
+
+	t.Run("json detected from content", func(t *testing.T) {
+		repos, err := ReadFile(Stdin, strings.NewReader(` ["go-chi/chi"]`), Options{Format: FormatAuto})
+		require.NoError(t, err)
+		require.Equal(t, []string{"go-chi/chi"}, repos)
+	})
+
+	t.Run("text from stdin", func(t *testing.T) {
+		repos, err := ReadFile(Stdin, strings.NewReader("go-chi/chi\nlabstack/echo\n"), Options{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"go-chi/chi", "labstack/echo"}, repos)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := ReadFile(filepath.Join(dir, "missing.txt"), nil, Options{})
+		require.ErrorContains(t, err, "failed to read repository list")
+	})
+
+	t.Run("invalid content names the file", func(t *testing.T) {
+		path := write("deps.json", "not json")
+		_, err := ReadFile(path, nil, Options{})
+		require.ErrorContains(t, err, "invalid repository list "+path)
+	})
+}
+
+func TestParseFormatterCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := formatter.NewCSVFormatter().Format(&buf, []*metrics.Repository{
+		{Owner: "go-chi", Name: "chi", HasCICD: true},
+		{Owner: "labstack", Name: "echo"},
+	})
+	require.NoError(t, err)
+
+	repos, err := Parse(buf.Bytes(), Options{Format: FormatCSV})
+	require.NoError(t, err)
+	require.Equal(t, []string{"go-chi/chi", "labstack/echo"}, repos)
+}
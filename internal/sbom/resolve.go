@@ -0,0 +1,157 @@
+package sbom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/kdimtriCP/gh-inspector/internal/github"
+	"github.com/kdimtriCP/gh-inspector/internal/gomod"
+)
+
+// Resolution is the repository a component maps to, or why it maps to none.
+type Resolution struct {
+	Component  Component
+	Repository string
+	Err        error
+}
+
+// Resolver maps SBOM components to GitHub repositories. It tries the VCS
+// references first, then the package URL, then the website and distribution
+// links. Go module purls go through modules, which resolves vanity import
+// paths.
+type Resolver struct {
+	modules *gomod.Resolver
+}
+
+func NewResolver(modules *gomod.Resolver) *Resolver {
+	if modules == nil {
+		modules = gomod.NewResolver(nil)
+	}
+	return &Resolver{modules: modules}
+}
+
+// ResolveAll resolves components in parallel, keeping their order.
+func (r *Resolver) ResolveAll(ctx context.Context, components []Component) []Resolution {
+	resolutions := make([]Resolution, len(components))
+	github.RunPool(len(components), gomod.ResolveWorkers, func(i int) {
+		repo, err := r.Repository(ctx, components[i])
+		resolutions[i] = Resolution{Component: components[i], Repository: repo, Err: err}
+	})
+	return resolutions
+}
+
+// Repository returns the GitHub repository of component in canonical form.
+func (r *Resolver) Repository(ctx context.Context, component Component) (string, error) {
+	for _, location := range component.VCS {
+		if repo, ok := repositoryFromURL(location); ok {
+			return repo, nil
+		}
+	}
+
+	var purlErr error
+	if component.PURL != "" {
+		repo, err := r.purlRepository(ctx, component.PURL)
+		if err == nil {
+			return repo, nil
+		}
+		purlErr = err
+	}
+
+	for _, link := range component.URLs {
+		if repo, ok := repositoryFromURL(link); ok {
+			return repo, nil
+		}
+	}
+
+	if purlErr != nil && !errors.Is(purlErr, errNoRepository) {
+		return "", purlErr
+	}
+	return "", fmt.Errorf("no GitHub repository in the VCS references, purl or external references of %s", component)
+}
+
+var errNoRepository = errors.New("purl names no GitHub repository")
+
+// purlRepository maps a package URL to a repository: github purls name it
+// directly, golang purls name a module path and any purl may carry a vcs_url
+// qualifier.
+func (r *Resolver) purlRepository(ctx context.Context, purl string) (string, error) {
+	rest, ok := strings.CutPrefix(purl, "pkg:")
+	if !ok {
+		return "", fmt.Errorf("invalid purl %q", purl)
+	}
+	rest, _, _ = strings.Cut(rest, "#")
+	rest, rawQualifiers, _ := strings.Cut(rest, "?")
+	if i := strings.LastIndex(rest, "@"); i >= 0 {
+		rest = rest[:i]
+	}
+	kind, path, _ := strings.Cut(rest, "/")
+	path, err := url.PathUnescape(strings.Trim(path, "/"))
+	if err != nil {
+		return "", fmt.Errorf("invalid purl %q: %w", purl, err)
+	}
+
+	if qualifiers, err := url.ParseQuery(rawQualifiers); err == nil {
+		if repo, ok := repositoryFromURL(qualifiers.Get("vcs_url")); ok {
+			return repo, nil
+		}
+	}
+
+	switch strings.ToLower(kind) {
+	case "github":
+		if repo, ok := repositoryFromURL(github.DefaultHost + "/" + path); ok {
+			return repo, nil
+		}
+	case "golang":
+		return r.modules.Repository(ctx, path)
+	}
+	return "", errNoRepository
+}
+
+// repositoryFromURL extracts a github.com repository from a URL, including
+// SPDX-style locations such as git+https://github.com/owner/name.git@v1.0.
+func repositoryFromURL(raw string) (string, bool) {
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), "git+")
+	if raw == "" {
+		return "", false
+	}
+	raw, _, _ = strings.Cut(raw, "#")
+	if scheme, rest, ok := strings.Cut(raw, "://"); ok {
+		host, path, _ := strings.Cut(rest, "/")
+		path, _, _ = strings.Cut(path, "@")
+		raw = scheme + "://" + host + "/" + path
+	}
+
+	ref, err := github.ParseReference(raw)
+	if err != nil || ref.Host != github.DefaultHost || reservedOwners[ref.Owner] {
+		return "", false
+	}
+	return ref.String(), true
+}
+
+// reservedOwners are first path segments of github.com pages that are not
+// repositories, such as github.com/sponsors/name or github.com/orgs/name/projects.
+var reservedOwners = map[string]bool{
+	"about":         true,
+	"advisories":    true,
+	"apps":          true,
+	"collections":   true,
+	"enterprises":   true,
+	"explore":       true,
+	"features":      true,
+	"login":         true,
+	"marketplace":   true,
+	"notifications": true,
+	"orgs":          true,
+	"organizations": true,
+	"pricing":       true,
+	"search":        true,
+	"settings":      true,
+	"site":          true,
+	"sponsors":      true,
+	"topics":        true,
+	"trending":      true,
+	"users":         true,
+}
@@ -0,0 +1,54 @@
+package sbom
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdimtriCP/gh-inspector/internal/gomod"
+)
+
+func TestResolveAll(t *testing.T) {
+	// Vanity import paths would be looked up here; none are expected.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request for %s", r.URL)
+	}))
+	t.Cleanup(srv.Close)
+
+	resolver := NewResolver(gomod.NewResolver(srv.Client()))
+	components := []Component{
+		{Name: "vcs first", VCS: []string{"https://gitlab.com/a/b", "git+ssh://git@github.com/Owner/Repo.git@v1.0#sub"}, PURL: "pkg:github/other/repo"},
+		{Name: "github purl", PURL: "pkg:github/package-url/purl-spec@244fd47e07d1004"},
+		{Name: "golang purl", PURL: "pkg:golang/github.com/go-chi/chi/v5@v5.2.2"},
+		{Name: "vcs_url qualifier", PURL: "pkg:npm/left-pad@1.3.0?vcs_url=git%2Bhttps://github.com/left-pad/left-pad.git"},
+		{Name: "website", PURL: "pkg:npm/%40types/express@4.17.21", URLs: []string{"https://example.com", "https://github.com/DefinitelyTyped/DefinitelyTyped/tree/master/types/express"}},
+		{Name: "nothing", Version: "1.0", PURL: "pkg:pypi/requests@2.31.0", URLs: []string{"https://requests.readthedocs.io"}},
+		{Name: "local module", PURL: "pkg:golang/internal/tools@v0.0.0"},
+		{Name: "sponsored", URLs: []string{"https://github.com/sponsors/someone", "https://github.com/orgs/acme/projects/1"}},
+	}
+
+	resolutions := resolver.ResolveAll(context.Background(), components)
+	require.Len(t, resolutions, len(components))
+
+	repos := make([]string, len(resolutions))
+	for i, resolution := range resolutions {
+		require.Equal(t, components[i], resolution.Component)
+		repos[i] = resolution.Repository
+	}
+	require.Equal(t, []string{
+		"owner/repo",
+		"package-url/purl-spec",
+		"go-chi/chi",
+		"left-pad/left-pad",
+		"definitelytyped/definitelytyped",
+		"",
+		"",
+		"",
+	}, repos)
+	require.ErrorContains(t, resolutions[5].Err, "no GitHub repository in the VCS references, purl or external references of nothing@1.0")
+	require.ErrorContains(t, resolutions[6].Err, "internal/tools is not a remote module path")
+	require.ErrorContains(t, resolutions[7].Err, "no GitHub repository")
+}
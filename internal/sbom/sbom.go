@@ -0,0 +1,186 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kdimtriCP/gh-inspector/internal/metrics"
+)
+
+type Format string
+
+const (
+	FormatCycloneDX Format = "CycloneDX"
+	FormatSPDX      Format = "SPDX"
+)
+
+// Component is an SBOM entry with the references that may lead to its source
+// repository: VCS locations, a package URL and links to its website or
+// distribution. Other links, such as advisories, often point at GitHub
+// without naming the component's repository.
+type Component struct {
+	Name    string
+	Version string
+	// CycloneDX bom-ref or SPDX identifier.
+	Ref  string
+	PURL string
+	VCS  []string
+	URLs []string
+}
+
+// Entry identifies the SBOM entry the component came from.
+func (c Component) Entry() metrics.SBOMComponent {
+	return metrics.SBOMComponent{Name: c.Name, Version: c.Version, Ref: c.Ref}
+}
+
+// String names the component as name@version.
+func (c Component) String() string {
+	if c.Version == "" {
+		return c.Name
+	}
+	return c.Name + "@" + c.Version
+}
+
+type cycloneDXDocument struct {
+	BOMFormat  string               `json:"bomFormat"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXComponent struct {
+	BOMRef             string `json:"bom-ref"`
+	Group              string `json:"group"`
+	Name               string `json:"name"`
+	Version            string `json:"version"`
+	PURL               string `json:"purl"`
+	ExternalReferences []struct {
+		Type string `json:"type"`
+		URL  string `json:"url"`
+	} `json:"externalReferences"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type spdxDocument struct {
+	SPDXVersion       string        `json:"spdxVersion"`
+	DocumentDescribes []string      `json:"documentDescribes"`
+	Packages          []spdxPackage `json:"packages"`
+	Relationships     []struct {
+		SPDXElementID      string `json:"spdxElementId"`
+		RelationshipType   string `json:"relationshipType"`
+		RelatedSPDXElement string `json:"relatedSpdxElement"`
+	} `json:"relationships"`
+}
+
+type spdxPackage struct {
+	SPDXID           string `json:"SPDXID"`
+	Name             string `json:"name"`
+	VersionInfo      string `json:"versionInfo"`
+	DownloadLocation string `json:"downloadLocation"`
+	Homepage         string `json:"homepage"`
+	ExternalRefs     []struct {
+		ReferenceType    string `json:"referenceType"`
+		ReferenceLocator string `json:"referenceLocator"`
+	} `json:"externalRefs"`
+}
+
+// ReadFile parses the SBOM at path. See Parse.
+func ReadFile(path string) (Format, []Component, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read SBOM %s: %w", path, err)
+	}
+	format, components, err := Parse(data)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid SBOM %s: %w", path, err)
+	}
+	return format, components, nil
+}
+
+// Parse reads the components of a CycloneDX or SPDX JSON document, in
+// document order. Nested CycloneDX components are included; the packages an
+// SPDX document describes are the subject of the SBOM and left out.
+func Parse(data []byte) (Format, []Component, error) {
+	var probe struct {
+		BOMFormat   string `json:"bomFormat"`
+		SPDXVersion string `json:"spdxVersion"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return "", nil, fmt.Errorf("expected a JSON document: %w", err)
+	}
+
+	switch {
+	case probe.BOMFormat == string(FormatCycloneDX):
+		var doc cycloneDXDocument
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return "", nil, fmt.Errorf("invalid CycloneDX document: %w", err)
+		}
+		return FormatCycloneDX, cycloneDXComponents(doc.Components, nil), nil
+	case strings.HasPrefix(probe.SPDXVersion, "SPDX-"):
+		var doc spdxDocument
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return "", nil, fmt.Errorf("invalid SPDX document: %w", err)
+		}
+		return FormatSPDX, spdxComponents(doc), nil
+	default:
+		return "", nil, fmt.Errorf("not a CycloneDX or SPDX JSON document")
+	}
+}
+
+func cycloneDXComponents(components []cycloneDXComponent, result []Component) []Component {
+	for _, c := range components {
+		name := c.Name
+		if c.Group != "" {
+			name = c.Group + "/" + c.Name
+		}
+		component := Component{Name: name, Version: c.Version, Ref: c.BOMRef, PURL: c.PURL}
+		for _, ref := range c.ExternalReferences {
+			switch ref.Type {
+			case "vcs":
+				component.VCS = append(component.VCS, ref.URL)
+			case "website", "distribution":
+				component.URLs = append(component.URLs, ref.URL)
+			}
+		}
+		result = append(result, component)
+		result = cycloneDXComponents(c.Components, result)
+	}
+	return result
+}
+
+func spdxComponents(doc spdxDocument) []Component {
+	described := make(map[string]bool)
+	for _, id := range doc.DocumentDescribes {
+		described[id] = true
+	}
+	for _, rel := range doc.Relationships {
+		if rel.SPDXElementID == "SPDXRef-DOCUMENT" && rel.RelationshipType == "DESCRIBES" {
+			described[rel.RelatedSPDXElement] = true
+		}
+	}
+
+	var result []Component
+	for _, pkg := range doc.Packages {
+		if described[pkg.SPDXID] {
+			continue
+		}
+		component := Component{Name: pkg.Name, Version: pkg.VersionInfo, Ref: pkg.SPDXID}
+		if spdxLocation(pkg.DownloadLocation) {
+			component.VCS = append(component.VCS, pkg.DownloadLocation)
+		}
+		if spdxLocation(pkg.Homepage) {
+			component.URLs = append(component.URLs, pkg.Homepage)
+		}
+		for _, ref := range pkg.ExternalRefs {
+			if ref.ReferenceType == "purl" && component.PURL == "" {
+				component.PURL = ref.ReferenceLocator
+			}
+		}
+		result = append(result, component)
+	}
+	return result
+}
+
+func spdxLocation(value string) bool {
+	return value != "" && value != "NONE" && value != "NOASSERTION"
+}
@@ -0,0 +1,114 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const cycloneDXDoc = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "metadata": {"component": {"bom-ref": "service", "name": "service"}},
+  "components": [
+    {
+      "bom-ref": "pkg:golang/github.com/go-chi/chi/v5@v5.2.2",
+      "name": "github.com/go-chi/chi/v5",
+      "version": "v5.2.2",
+      "purl": "pkg:golang/github.com/go-chi/chi/v5@v5.2.2",
+      "externalReferences": [{"type": "vcs", "url": "https://github.com/go-chi/chi"}]
+    },
+    {
+      "bom-ref": "express",
+      "group": "@types",
+      "name": "express",
+      "version": "4.17.21",
+      "purl": "pkg:npm/%40types/express@4.17.21",
+      "externalReferences": [
+        {"type": "website", "url": "https://github.com/DefinitelyTyped/DefinitelyTyped"},
+        {"type": "advisories", "url": "https://github.com/advisories/GHSA-xxxx-xxxx-xxxx"}
+      ],
+      "components": [{"bom-ref": "nested", "name": "nested"}]
+    }
+  ]
+}`
+
+const spdxDoc = `{
+  "spdxVersion": "SPDX-2.3",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "documentDescribes": ["SPDXRef-service"],
+  "packages": [
+    {"SPDXID": "SPDXRef-service", "name": "service", "downloadLocation": "NOASSERTION"},
+    {
+      "SPDXID": "SPDXRef-Package-yaml",
+      "name": "gopkg.in/yaml.v3",
+      "versionInfo": "v3.0.1",
+      "downloadLocation": "git+https://github.com/go-yaml/yaml.git@v3.0.1",
+      "homepage": "NONE",
+      "externalRefs": [
+        {"referenceCategory": "SECURITY", "referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:yaml:yaml:3.0.1:*:*:*:*:*:*:*"},
+        {"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:golang/gopkg.in/yaml.v3@v3.0.1"},
+        {"referenceCategory": "SECURITY", "referenceType": "advisory", "referenceLocator": "https://github.com/advisories/GHSA-xxxx-xxxx-xxxx"}
+      ]
+    },
+    {"SPDXID": "SPDXRef-Package-root", "name": "root", "downloadLocation": "NOASSERTION", "homepage": "https://example.com/root"}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-Package-root"}
+  ]
+}`
+
+func TestParse(t *testing.T) {
+	t.Run("cyclonedx", func(t *testing.T) {
+		format, components, err := Parse([]byte(cycloneDXDoc))
+		require.NoError(t, err)
+		require.Equal(t, FormatCycloneDX, format)
+		require.Equal(t, []Component{
+			{
+				Name:    "github.com/go-chi/chi/v5",
+				Version: "v5.2.2",
+				Ref:     "pkg:golang/github.com/go-chi/chi/v5@v5.2.2",
+				PURL:    "pkg:golang/github.com/go-chi/chi/v5@v5.2.2",
+				VCS:     []string{"https://github.com/go-chi/chi"},
+			},
+			{
+				Name:    "@types/express",
+				Version: "4.17.21",
+				Ref:     "express",
+				PURL:    "pkg:npm/%40types/express@4.17.21",
+				URLs:    []string{"https://github.com/DefinitelyTyped/DefinitelyTyped"},
+			},
+			{Name: "nested", Ref: "nested"},
+		}, components)
+	})
+
+	t.Run("spdx", func(t *testing.T) {
+		format, components, err := Parse([]byte(spdxDoc))
+		require.NoError(t, err)
+		require.Equal(t, FormatSPDX, format)
+		require.Equal(t, []Component{
+			{
+				Name:    "gopkg.in/yaml.v3",
+				Version: "v3.0.1",
+				Ref:     "SPDXRef-Package-yaml",
+				PURL:    "pkg:golang/gopkg.in/yaml.v3@v3.0.1",
+				VCS:     []string{"git+https://github.com/go-yaml/yaml.git@v3.0.1"},
+			},
+		}, components)
+	})
+
+	t.Run("unknown document", func(t *testing.T) {
+		_, _, err := Parse([]byte(`{"packages": []}`))
+		require.ErrorContains(t, err, "not a CycloneDX or SPDX JSON document")
+	})
+
+	t.Run("not json", func(t *testing.T) {
+		_, _, err := Parse([]byte(`<bom/>`))
+		require.ErrorContains(t, err, "expected a JSON document")
+	})
+}
+
+func TestComponentString(t *testing.T) {
+	require.Equal(t, "chi@v5.2.2", Component{Name: "chi", Version: "v5.2.2"}.String())
+	require.Equal(t, "chi", Component{Name: "chi"}.String())
+}
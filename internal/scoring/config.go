@@ -1,41 +1,76 @@
 package scoring
 
 type Config struct {
-	Weights Weights `yaml:"weights"`
+	Weights Weights `yaml:"weights" mapstructure:"weights"`
 }
 
 type Weights struct {
-	Stars            float64 `yaml:"stars"`
-	Forks            float64 `yaml:"forks"`
-	RecentActivity   float64 `yaml:"recent_activity"`
-	OpenIssues       float64 `yaml:"open_issues"`
-	OpenPRs          float64 `yaml:"open_prs"`
-	HasLicense       float64 `yaml:"has_license"`
-	HasCICD          float64 `yaml:"has_cicd"`
-	HasContributing  float64 `yaml:"has_contributing"`
-	ReleaseFrequency float64 `yaml:"release_frequency"`
-	HasReadme        float64 `yaml:"has_readme"`
-	HasCodeOfConduct float64 `yaml:"has_code_of_conduct"`
-	HasSecurity      float64 `yaml:"has_security"`
-	Watchers         float64 `yaml:"watchers"`
+	Stars               float64 `yaml:"stars" mapstructure:"stars"`
+	Forks               float64 `yaml:"forks" mapstructure:"forks"`
+	RecentActivity      float64 `yaml:"recent_activity" mapstructure:"recent_activity"`
+	OpenIssues          float64 `yaml:"open_issues" mapstructure:"open_issues"`
+	OpenPRs             float64 `yaml:"open_prs" mapstructure:"open_prs"`
+	HasLicense          float64 `yaml:"has_license" mapstructure:"has_license"`
+	HasCICD             float64 `yaml:"has_cicd" mapstructure:"has_cicd"`
+	HasContributing     float64 `yaml:"has_contributing" mapstructure:"has_contributing"`
+	ReleaseFrequency    float64 `yaml:"release_frequency" mapstructure:"release_frequency"`
+	HasReadme           float64 `yaml:"has_readme" mapstructure:"has_readme"`
+	HasCodeOfConduct    float64 `yaml:"has_code_of_conduct" mapstructure:"has_code_of_conduct"`
+	Watchers            float64 `yaml:"watchers" mapstructure:"watchers"`
+	Contributors        float64 `yaml:"contributors" mapstructure:"contributors"`
+	BusFactor           float64 `yaml:"bus_factor" mapstructure:"bus_factor"`
+	IssueResponsiveness float64 `yaml:"issue_responsiveness" mapstructure:"issue_responsiveness"`
+	PRThroughput        float64 `yaml:"pr_throughput" mapstructure:"pr_throughput"`
+	PRReviews           float64 `yaml:"pr_reviews" mapstructure:"pr_reviews"`
+	ReleaseQuality      float64 `yaml:"release_quality" mapstructure:"release_quality"`
+	Security            float64 `yaml:"security" mapstructure:"security"`
+	// Weight of the former security policy check, which the security
+	// sub-score took over. Configurations that still set it weigh the
+	// security sub-score with it instead of Security.
+	HasSecurity float64 `yaml:"has_security" mapstructure:"has_security"`
+	// Optional metadata weights, off by default.
+	Maturity        float64 `yaml:"maturity" mapstructure:"maturity"`
+	Discoverability float64 `yaml:"discoverability" mapstructure:"discoverability"`
 }
 
+// DefaultConfig returns the default weights, which sum to 1. The original
+// weights favored popularity, with stars at 0.20 and watchers at 0.09; the
+// contributor and bus-factor weights took 0.03 each from those two, and the
+// issue, pull request and release sub-scores that followed took theirs from
+// stars, forks, open issues, open pull requests and release frequency, so
+// that maintenance counts for more than popularity. The metadata weights are
+// off.
 func DefaultConfig() *Config {
 	return &Config{
 		Weights: Weights{
-			Stars:            0.20,
-			Forks:            0.08,
-			RecentActivity:   0.18,
-			OpenIssues:       0.08,
-			OpenPRs:          0.04,
-			HasLicense:       0.04,
-			HasCICD:          0.04,
-			HasContributing:  0.04,
-			ReleaseFrequency: 0.12,
-			HasReadme:        0.03,
-			HasCodeOfConduct: 0.03,
-			HasSecurity:      0.03,
-			Watchers:         0.09,
+			Stars:               0.14,
+			Forks:               0.06,
+			RecentActivity:      0.18,
+			OpenIssues:          0.04,
+			OpenPRs:             0.02,
+			HasLicense:          0.04,
+			HasCICD:             0.04,
+			HasContributing:     0.04,
+			ReleaseFrequency:    0.08,
+			HasReadme:           0.03,
+			HasCodeOfConduct:    0.03,
+			Watchers:            0.06,
+			Contributors:        0.03,
+			BusFactor:           0.03,
+			IssueResponsiveness: 0.06,
+			PRThroughput:        0.03,
+			PRReviews:           0.02,
+			ReleaseQuality:      0.04,
+			Security:            0.03,
 		},
 	}
 }
+
+// SecurityWeight returns the weight of the security sub-score, honoring the
+// has_security key of older configurations.
+func (w Weights) SecurityWeight() float64 {
+	if w.HasSecurity != 0 {
+		return w.HasSecurity
+	}
+	return w.Security
+}
@@ -13,16 +13,45 @@ type RepositoryMetrics interface {
 	GetOpenIssues() int
 	GetOpenPRs() int
 	GetLastCommitDate() time.Time
+	GetWeeklyCommits() []int
 	GetIsArchived() bool
 	GetHasLicense() bool
 	GetHasCICD() bool
 	GetHasContributing() bool
 	GetReleaseCount() int
 	GetLastReleaseDate() time.Time
+	GetMedianReleaseInterval() time.Duration
+	GetSemverReleaseShare() float64
+	GetPrereleaseShare() float64
+	GetReleaseNotesShare() float64
+	GetRecentMajorBumps() int
 	GetHasReadme() bool
 	GetHasCodeOfConduct() bool
 	GetHasSecurity() bool
+	GetBranchProtected() *bool
+	GetRequiredReviews() int
+	GetRequiresStatusChecks() bool
+	GetVulnerabilityAlerts() *bool
+	GetSecurityAdvisories() int
 	GetWatchers() int
+	GetContributorCount() int
+	GetTopContributorShare() float64
+	GetBusFactor() int
+	GetRecentIssueCount() int
+	GetMedianIssueResponse() time.Duration
+	GetMedianIssueCloseTime() time.Duration
+	GetUnansweredIssueShare() float64
+	GetRecentMergedPRs() int
+	GetRecentClosedPRs() int
+	GetMedianPRMergeTime() time.Duration
+	GetApprovedMergeShare() float64
+	GetStalePRCount() int
+
+	// Repository metadata, scored only when the maturity and discoverability
+	// weights are set.
+	GetCreatedAt() time.Time
+	GetTopics() []string
+	GetHomepageURL() string
 }
 
 type Scorer struct {
@@ -50,15 +79,30 @@ func (s *Scorer) Score(metrics RepositoryMetrics) float64 {
 	forksScore := math.Min(math.Log10(float64(metrics.GetForks()+1))/4.5, 1.0)
 	score += forksScore * weights.Forks
 
-	activityScore := s.calculateActivityScore(metrics.GetLastCommitDate())
+	activityScore := s.calculateCadenceScore(metrics.GetWeeklyCommits(), metrics.GetLastCommitDate())
 	score += activityScore * weights.RecentActivity
 
 	issuesScore := s.calculateIssuesScore(metrics.GetOpenIssues())
 	score += issuesScore * weights.OpenIssues
 
+	responsivenessScore := s.calculateResponsivenessScore(
+		metrics.GetRecentIssueCount(),
+		metrics.GetMedianIssueResponse(),
+		metrics.GetMedianIssueCloseTime(),
+		metrics.GetUnansweredIssueShare(),
+	)
+	score += responsivenessScore * weights.IssueResponsiveness
+
 	prsScore := s.calculatePRsScore(metrics.GetOpenPRs())
 	score += prsScore * weights.OpenPRs
 
+	mergedPRs := metrics.GetRecentMergedPRs()
+	prThroughputScore := s.calculatePRThroughputScore(mergedPRs, metrics.GetRecentClosedPRs(), metrics.GetMedianPRMergeTime())
+	score += prThroughputScore * weights.PRThroughput
+
+	prReviewsScore := s.calculatePRReviewsScore(mergedPRs, metrics.GetApprovedMergeShare(), metrics.GetStalePRCount())
+	score += prReviewsScore * weights.PRReviews
+
 	if metrics.GetHasLicense() {
 		score += weights.HasLicense
 	}
@@ -74,16 +118,46 @@ func (s *Scorer) Score(metrics RepositoryMetrics) float64 {
 	if metrics.GetHasCodeOfConduct() {
 		score += weights.HasCodeOfConduct
 	}
-	if metrics.GetHasSecurity() {
-		score += weights.HasSecurity
-	}
+
+	securityScore := s.calculateSecurityScore(
+		metrics.GetBranchProtected(),
+		metrics.GetRequiredReviews(),
+		metrics.GetRequiresStatusChecks(),
+		metrics.GetVulnerabilityAlerts(),
+		metrics.GetSecurityAdvisories(),
+		metrics.GetHasSecurity(),
+	)
+	score += securityScore * weights.SecurityWeight()
 
 	watchersScore := s.calculateWatchersScore(metrics.GetWatchers())
 	score += watchersScore * weights.Watchers
 
-	releaseScore := s.calculateReleaseFrequencyScore(metrics.GetReleaseCount(), metrics.GetLastReleaseDate())
+	releaseCount := metrics.GetReleaseCount()
+	releaseScore := s.calculateReleaseFrequencyScore(releaseCount, metrics.GetLastReleaseDate())
 	score += releaseScore * weights.ReleaseFrequency
 
+	releaseQualityScore := s.calculateReleaseQualityScore(
+		releaseCount,
+		metrics.GetMedianReleaseInterval(),
+		metrics.GetSemverReleaseShare(),
+		metrics.GetPrereleaseShare(),
+		metrics.GetReleaseNotesShare(),
+		metrics.GetRecentMajorBumps(),
+	)
+	score += releaseQualityScore * weights.ReleaseQuality
+
+	contributorsScore := s.calculateContributorsScore(metrics.GetContributorCount())
+	score += contributorsScore * weights.Contributors
+
+	busFactorScore := s.calculateBusFactorScore(metrics.GetBusFactor(), metrics.GetTopContributorShare())
+	score += busFactorScore * weights.BusFactor
+
+	maturityScore := s.calculateMaturityScore(metrics.GetCreatedAt())
+	score += maturityScore * weights.Maturity
+
+	discoverabilityScore := s.calculateDiscoverabilityScore(metrics.GetTopics(), metrics.GetHomepageURL())
+	score += discoverabilityScore * weights.Discoverability
+
 	// Normalize to 0-100 scale
 	return math.Min(score*100, 100)
 }
@@ -111,6 +185,63 @@ func (s *Scorer) calculateActivityScore(lastCommitDate time.Time) float64 {
 	}
 }
 
+// calculateCadenceScore averages how consistently commits landed over the
+// past year, whether the last quarter kept up with the quarters before it, and
+// how recent the last commit is. Without a weekly series only the last commit
+// date is scored.
+func (s *Scorer) calculateCadenceScore(weeklyCommits []int, lastCommitDate time.Time) float64 {
+	recencyScore := s.calculateActivityScore(lastCommitDate)
+	if len(weeklyCommits) == 0 {
+		return recencyScore
+	}
+
+	activeWeeks := 0
+	for _, commits := range weeklyCommits {
+		if commits > 0 {
+			activeWeeks++
+		}
+	}
+	consistencyScore := float64(activeWeeks) / float64(len(weeklyCommits))
+
+	return (consistencyScore + s.calculateTrendScore(weeklyCommits) + recencyScore) / 3.0
+}
+
+// calculateTrendScore compares the average weekly commits of the last 13 weeks
+// with the weeks before. Keeping pace or growing scores fully. After a quiet
+// stretch, the share of active recent weeks is used instead, so a single
+// commit does not count as a revival.
+func (s *Scorer) calculateTrendScore(weeklyCommits []int) float64 {
+	const recentWeeks = 13
+
+	split := max(len(weeklyCommits)-recentWeeks, 0)
+	earlier, recent := weeklyCommits[:split], weeklyCommits[split:]
+
+	earlierAvg := averageCommits(earlier)
+	if earlierAvg == 0 {
+		activeWeeks := 0
+		for _, commits := range recent {
+			if commits > 0 {
+				activeWeeks++
+			}
+		}
+		return float64(activeWeeks) / float64(recentWeeks)
+	}
+
+	return math.Min(averageCommits(recent)/earlierAvg, 1.0)
+}
+
+func averageCommits(weeklyCommits []int) float64 {
+	if len(weeklyCommits) == 0 {
+		return 0
+	}
+
+	total := 0
+	for _, commits := range weeklyCommits {
+		total += commits
+	}
+	return float64(total) / float64(len(weeklyCommits))
+}
+
 func (s *Scorer) calculateIssuesScore(openIssues int) float64 {
 	if openIssues == 0 {
 		return 1.0
@@ -120,6 +251,50 @@ func (s *Scorer) calculateIssuesScore(openIssues int) float64 {
 	return math.Max(0, 1.0-math.Log10(issueRatio+1)/5.0)
 }
 
+// calculateResponsivenessScore averages how fast maintainers answer and close
+// recent issues with the share of issues they answered. Repositories without
+// recent issues have nothing to answer and score fully, like an empty tracker
+// does in calculateIssuesScore.
+func (s *Scorer) calculateResponsivenessScore(recentIssues int, medianResponse, medianClose time.Duration, unansweredShare float64) float64 {
+	if recentIssues == 0 {
+		return 1.0
+	}
+
+	var responseScore float64
+	if medianResponse > 0 {
+		hoursToResponse := medianResponse.Hours()
+		switch {
+		case hoursToResponse <= 24:
+			responseScore = 1.0
+		case hoursToResponse <= 72:
+			responseScore = 0.8
+		case hoursToResponse <= 168:
+			responseScore = 0.6
+		case hoursToResponse <= 720:
+			responseScore = 0.4
+		default:
+			responseScore = 0.2
+		}
+	}
+
+	var closeScore float64
+	if medianClose > 0 {
+		daysToClose := medianClose.Hours() / 24
+		switch {
+		case daysToClose <= 7:
+			closeScore = 1.0
+		case daysToClose <= 30:
+			closeScore = 0.7
+		case daysToClose <= 90:
+			closeScore = 0.4
+		default:
+			closeScore = 0.2
+		}
+	}
+
+	return (responseScore + closeScore + (1.0 - unansweredShare)) / 3.0
+}
+
 func (s *Scorer) calculatePRsScore(openPRs int) float64 {
 	if openPRs == 0 {
 		return 1.0
@@ -129,6 +304,47 @@ func (s *Scorer) calculatePRsScore(openPRs int) float64 {
 	return math.Max(0, 1.0-math.Log10(prRatio+1)/4.0)
 }
 
+// calculatePRThroughputScore averages the share of recently closed pull
+// requests that were merged with how quickly they were merged. Without recent
+// pull requests there is nothing to land, which scores fully like
+// calculatePRsScore does for no open pull requests.
+func (s *Scorer) calculatePRThroughputScore(merged, closedUnmerged int, medianMergeTime time.Duration) float64 {
+	if merged+closedUnmerged == 0 {
+		return 1.0
+	}
+
+	mergeRatio := float64(merged) / float64(merged+closedUnmerged)
+	if merged == 0 {
+		return mergeRatio / 2.0
+	}
+
+	var leadTimeScore float64
+	daysToMerge := medianMergeTime.Hours() / 24
+	switch {
+	case daysToMerge <= 1:
+		leadTimeScore = 1.0
+	case daysToMerge <= 7:
+		leadTimeScore = 0.8
+	case daysToMerge <= 30:
+		leadTimeScore = 0.5
+	default:
+		leadTimeScore = 0.2
+	}
+
+	return (mergeRatio + leadTimeScore) / 2.0
+}
+
+// calculatePRReviewsScore averages the share of merged pull requests with an
+// approving review with a penalty for open pull requests left stale.
+func (s *Scorer) calculatePRReviewsScore(merged int, approvedShare float64, stalePRs int) float64 {
+	staleScore := math.Max(0, 1.0-math.Log10(float64(stalePRs+1))/2.0)
+	if merged == 0 {
+		return staleScore
+	}
+
+	return (approvedShare + staleScore) / 2.0
+}
+
 func (s *Scorer) calculateReleaseFrequencyScore(releaseCount int, lastReleaseDate time.Time) float64 {
 	if releaseCount == 0 {
 		return 0.0
@@ -159,6 +375,116 @@ func (s *Scorer) calculateReleaseFrequencyScore(releaseCount int, lastReleaseDat
 	return (recencyScore + frequencyScore) / 2.0
 }
 
+// calculateReleaseQualityScore averages how regularly releases ship, the
+// shares of semver tags, stable releases and releases with notes, and API
+// stability: one major version bump a year is fine, more lower the score.
+func (s *Scorer) calculateReleaseQualityScore(releaseCount int, medianInterval time.Duration, semverShare, prereleaseShare, notesShare float64, majorBumps int) float64 {
+	if releaseCount == 0 {
+		return 0.0
+	}
+
+	var intervalScore float64
+	if medianInterval > 0 {
+		daysBetween := medianInterval.Hours() / 24
+		switch {
+		case daysBetween <= 30:
+			intervalScore = 1.0
+		case daysBetween <= 90:
+			intervalScore = 0.8
+		case daysBetween <= 180:
+			intervalScore = 0.5
+		default:
+			intervalScore = 0.2
+		}
+	}
+
+	stabilityScore := math.Max(0, 1.0-float64(max(majorBumps-1, 0))/2.0)
+
+	return (intervalScore + semverShare + (1.0 - prereleaseShare) + notesShare + stabilityScore) / 5.0
+}
+
+// calculateSecurityScore averages default branch protection, where required
+// reviews and status checks complete it, enabled vulnerability alerts, a
+// security policy, and published advisories. Advisories show a working
+// disclosure process, so having none scores half. Protection and alerts are
+// nil when the token may not read them and are left out of the average
+// rather than counted as disabled.
+func (s *Scorer) calculateSecurityScore(branchProtected *bool, requiredReviews int, requiresStatusChecks bool, vulnerabilityAlerts *bool, advisories int, hasPolicy bool) float64 {
+	var total float64
+	var parts int
+
+	if branchProtected != nil {
+		parts++
+		if *branchProtected {
+			total += 0.5
+			if requiredReviews > 0 {
+				total += 0.25
+			}
+			if requiresStatusChecks {
+				total += 0.25
+			}
+		}
+	}
+
+	if vulnerabilityAlerts != nil {
+		parts++
+		if *vulnerabilityAlerts {
+			total += 1.0
+		}
+	}
+
+	parts++
+	if hasPolicy {
+		total += 1.0
+	}
+
+	parts++
+	if advisories > 0 {
+		total += 1.0
+	} else {
+		total += 0.5
+	}
+
+	return total / float64(parts)
+}
+
+// calculateMaturityScore grows with the repository's age and is full at three
+// years.
+func (s *Scorer) calculateMaturityScore(createdAt time.Time) float64 {
+	if createdAt.IsZero() {
+		return 0.0
+	}
+
+	ageYears := time.Since(createdAt).Hours() / 24 / 365
+	return math.Max(0, math.Min(ageYears/3.0, 1.0))
+}
+
+func (s *Scorer) calculateDiscoverabilityScore(topics []string, homepageURL string) float64 {
+	score := 0.0
+	if len(topics) > 0 {
+		score += 0.5
+	}
+	if homepageURL != "" {
+		score += 0.5
+	}
+	return score
+}
+
 func (s *Scorer) calculateWatchersScore(watchers int) float64 {
 	return math.Min(math.Log10(float64(watchers+1))/4.0, 1.0)
 }
+
+func (s *Scorer) calculateContributorsScore(contributors int) float64 {
+	return math.Min(math.Log10(float64(contributors+1))/1.5, 1.0)
+}
+
+// calculateBusFactorScore rewards work spread over several people: a bus
+// factor of 4 or more scores fully, and a dominant top contributor lowers it.
+func (s *Scorer) calculateBusFactorScore(busFactor int, topContributorShare float64) float64 {
+	if busFactor == 0 {
+		return 0.0
+	}
+
+	busFactorScore := math.Min(float64(busFactor)/4.0, 1.0)
+	return (busFactorScore + (1.0 - topContributorShare)) / 2.0
+}
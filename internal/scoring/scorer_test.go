@@ -2,6 +2,7 @@ package scoring
 
 import (
 	"math"
+	"reflect"
 	"testing"
 	"time"
 
@@ -29,8 +30,28 @@ func TestNewScorer(t *testing.T) {
 	})
 }
 
+func TestDefaultConfig(t *testing.T) {
+	weights := DefaultConfig().Weights
+
+	var sum float64
+	values := reflect.ValueOf(weights)
+	for i := 0; i < values.NumField(); i++ {
+		sum += values.Field(i).Float()
+	}
+	require.InDelta(t, 1.0, sum, 1e-9, "default weights should sum to 1")
+
+	require.Equal(t, 0.14, weights.Stars)
+	require.Equal(t, 0.06, weights.Watchers)
+	require.Equal(t, 0.06, weights.Forks)
+	require.Equal(t, 0.03, weights.Contributors)
+	require.Equal(t, 0.03, weights.BusFactor)
+	require.Zero(t, weights.Maturity)
+	require.Zero(t, weights.Discoverability)
+}
+
 func TestScore(t *testing.T) {
 	scorer := NewScorer(DefaultConfig())
+	yes := true
 
 	tests := []struct {
 		name      string
@@ -55,15 +76,41 @@ func TestScore(t *testing.T) {
 				m.EXPECT().GetOpenIssues().Return(0)
 				m.EXPECT().GetOpenPRs().Return(0)
 				m.EXPECT().GetLastCommitDate().Return(time.Now())
+				m.EXPECT().GetWeeklyCommits().Return(weeklyCommits(52, 25))
 				m.EXPECT().GetHasLicense().Return(true)
 				m.EXPECT().GetHasCICD().Return(true)
 				m.EXPECT().GetHasContributing().Return(true)
 				m.EXPECT().GetReleaseCount().Return(20)
 				m.EXPECT().GetLastReleaseDate().Return(time.Now().AddDate(0, 0, -7))
+				m.EXPECT().GetMedianReleaseInterval().Return(14 * 24 * time.Hour)
+				m.EXPECT().GetSemverReleaseShare().Return(1.0)
+				m.EXPECT().GetPrereleaseShare().Return(0.1)
+				m.EXPECT().GetReleaseNotesShare().Return(1.0)
+				m.EXPECT().GetRecentMajorBumps().Return(0)
 				m.EXPECT().GetHasReadme().Return(true)
 				m.EXPECT().GetHasCodeOfConduct().Return(true)
 				m.EXPECT().GetHasSecurity().Return(true)
+				m.EXPECT().GetBranchProtected().Return(&yes)
+				m.EXPECT().GetRequiredReviews().Return(2)
+				m.EXPECT().GetRequiresStatusChecks().Return(true)
+				m.EXPECT().GetVulnerabilityAlerts().Return(&yes)
+				m.EXPECT().GetSecurityAdvisories().Return(3)
+				m.EXPECT().GetCreatedAt().Return(time.Now().AddDate(-8, 0, 0))
+				m.EXPECT().GetTopics().Return([]string{"kubernetes", "containers"})
+				m.EXPECT().GetHomepageURL().Return("https://kubernetes.io")
 				m.EXPECT().GetWatchers().Return(5000)
+				m.EXPECT().GetContributorCount().Return(40)
+				m.EXPECT().GetTopContributorShare().Return(0.15)
+				m.EXPECT().GetBusFactor().Return(6)
+				m.EXPECT().GetRecentIssueCount().Return(30)
+				m.EXPECT().GetMedianIssueResponse().Return(6 * time.Hour)
+				m.EXPECT().GetMedianIssueCloseTime().Return(72 * time.Hour)
+				m.EXPECT().GetUnansweredIssueShare().Return(0.05)
+				m.EXPECT().GetRecentMergedPRs().Return(40)
+				m.EXPECT().GetRecentClosedPRs().Return(5)
+				m.EXPECT().GetMedianPRMergeTime().Return(20 * time.Hour)
+				m.EXPECT().GetApprovedMergeShare().Return(0.95)
+				m.EXPECT().GetStalePRCount().Return(0)
 			},
 			wantMin: 85.0,
 			wantMax: 100.0,
@@ -77,15 +124,41 @@ func TestScore(t *testing.T) {
 				m.EXPECT().GetOpenIssues().Return(50)
 				m.EXPECT().GetOpenPRs().Return(20)
 				m.EXPECT().GetLastCommitDate().Return(time.Now().AddDate(-2, 0, 0))
+				m.EXPECT().GetWeeklyCommits().Return(nil)
 				m.EXPECT().GetHasLicense().Return(false)
 				m.EXPECT().GetHasCICD().Return(false)
 				m.EXPECT().GetHasContributing().Return(false)
 				m.EXPECT().GetReleaseCount().Return(0)
 				m.EXPECT().GetLastReleaseDate().Return(time.Time{})
+				m.EXPECT().GetMedianReleaseInterval().Return(time.Duration(0))
+				m.EXPECT().GetSemverReleaseShare().Return(0.0)
+				m.EXPECT().GetPrereleaseShare().Return(0.0)
+				m.EXPECT().GetReleaseNotesShare().Return(0.0)
+				m.EXPECT().GetRecentMajorBumps().Return(0)
 				m.EXPECT().GetHasReadme().Return(false)
 				m.EXPECT().GetHasCodeOfConduct().Return(false)
 				m.EXPECT().GetHasSecurity().Return(false)
+				m.EXPECT().GetBranchProtected().Return(nil)
+				m.EXPECT().GetRequiredReviews().Return(0)
+				m.EXPECT().GetRequiresStatusChecks().Return(false)
+				m.EXPECT().GetVulnerabilityAlerts().Return(nil)
+				m.EXPECT().GetSecurityAdvisories().Return(0)
+				m.EXPECT().GetCreatedAt().Return(time.Now().AddDate(-6, 0, 0))
+				m.EXPECT().GetTopics().Return(nil)
+				m.EXPECT().GetHomepageURL().Return("")
 				m.EXPECT().GetWatchers().Return(10)
+				m.EXPECT().GetContributorCount().Return(1)
+				m.EXPECT().GetTopContributorShare().Return(1.0)
+				m.EXPECT().GetBusFactor().Return(1)
+				m.EXPECT().GetRecentIssueCount().Return(12)
+				m.EXPECT().GetMedianIssueResponse().Return(time.Duration(0))
+				m.EXPECT().GetMedianIssueCloseTime().Return(time.Duration(0))
+				m.EXPECT().GetUnansweredIssueShare().Return(1.0)
+				m.EXPECT().GetRecentMergedPRs().Return(0)
+				m.EXPECT().GetRecentClosedPRs().Return(3)
+				m.EXPECT().GetMedianPRMergeTime().Return(time.Duration(0))
+				m.EXPECT().GetApprovedMergeShare().Return(0.0)
+				m.EXPECT().GetStalePRCount().Return(15)
 			},
 			wantMin: 0.0,
 			wantMax: 30.0,
@@ -99,15 +172,41 @@ func TestScore(t *testing.T) {
 				m.EXPECT().GetOpenIssues().Return(10)
 				m.EXPECT().GetOpenPRs().Return(5)
 				m.EXPECT().GetLastCommitDate().Return(time.Now().AddDate(0, -1, 0))
+				m.EXPECT().GetWeeklyCommits().Return(weeklyCommits(52, 2))
 				m.EXPECT().GetHasLicense().Return(true)
 				m.EXPECT().GetHasCICD().Return(true)
 				m.EXPECT().GetHasContributing().Return(false)
 				m.EXPECT().GetReleaseCount().Return(5)
 				m.EXPECT().GetLastReleaseDate().Return(time.Now().AddDate(0, -2, 0))
+				m.EXPECT().GetMedianReleaseInterval().Return(60 * 24 * time.Hour)
+				m.EXPECT().GetSemverReleaseShare().Return(0.8)
+				m.EXPECT().GetPrereleaseShare().Return(0.2)
+				m.EXPECT().GetReleaseNotesShare().Return(0.6)
+				m.EXPECT().GetRecentMajorBumps().Return(1)
 				m.EXPECT().GetHasReadme().Return(true)
 				m.EXPECT().GetHasCodeOfConduct().Return(false)
 				m.EXPECT().GetHasSecurity().Return(false)
+				m.EXPECT().GetBranchProtected().Return(&yes)
+				m.EXPECT().GetRequiredReviews().Return(1)
+				m.EXPECT().GetRequiresStatusChecks().Return(false)
+				m.EXPECT().GetVulnerabilityAlerts().Return(nil)
+				m.EXPECT().GetSecurityAdvisories().Return(0)
+				m.EXPECT().GetCreatedAt().Return(time.Now().AddDate(-2, 0, 0))
+				m.EXPECT().GetTopics().Return([]string{"cli"})
+				m.EXPECT().GetHomepageURL().Return("")
 				m.EXPECT().GetWatchers().Return(500)
+				m.EXPECT().GetContributorCount().Return(8)
+				m.EXPECT().GetTopContributorShare().Return(0.4)
+				m.EXPECT().GetBusFactor().Return(2)
+				m.EXPECT().GetRecentIssueCount().Return(10)
+				m.EXPECT().GetMedianIssueResponse().Return(48 * time.Hour)
+				m.EXPECT().GetMedianIssueCloseTime().Return(20 * 24 * time.Hour)
+				m.EXPECT().GetUnansweredIssueShare().Return(0.3)
+				m.EXPECT().GetRecentMergedPRs().Return(8)
+				m.EXPECT().GetRecentClosedPRs().Return(4)
+				m.EXPECT().GetMedianPRMergeTime().Return(5 * 24 * time.Hour)
+				m.EXPECT().GetApprovedMergeShare().Return(0.5)
+				m.EXPECT().GetStalePRCount().Return(3)
 			},
 			wantMin: 40.0,
 			wantMax: 70.0,
@@ -189,6 +288,46 @@ func TestCalculateActivityScore(t *testing.T) {
 	}
 }
 
+func weeklyCommits(weeks, commits int) []int {
+	series := make([]int, weeks)
+	for i := range series {
+		series[i] = commits
+	}
+	return series
+}
+
+func TestCalculateCadenceScore(t *testing.T) {
+	scorer := NewScorer(DefaultConfig())
+
+	revived := make([]int, 52)
+	revived[51] = 1
+
+	declining := weeklyCommits(52, 10)
+	for i := 39; i < 52; i++ {
+		declining[i] = 5
+	}
+
+	tests := []struct {
+		name           string
+		weeklyCommits  []int
+		lastCommitDate time.Time
+		want           float64
+	}{
+		{name: "no series", weeklyCommits: nil, lastCommitDate: time.Now().AddDate(0, 0, -20), want: 0.8},
+		{name: "steady", weeklyCommits: weeklyCommits(52, 10), lastCommitDate: time.Now(), want: 1.0},
+		{name: "single commit after a quiet year", weeklyCommits: revived, lastCommitDate: time.Now(), want: (1.0/52.0 + 1.0/13.0 + 1.0) / 3.0},
+		{name: "declining", weeklyCommits: declining, lastCommitDate: time.Now(), want: (1.0 + 0.5 + 1.0) / 3.0},
+		{name: "abandoned", weeklyCommits: make([]int, 52), lastCommitDate: time.Now().AddDate(-2, 0, 0), want: 0.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scorer.calculateCadenceScore(tt.weeklyCommits, tt.lastCommitDate)
+			require.InDelta(t, tt.want, got, 0.0001)
+		})
+	}
+}
+
 func TestCalculateIssuesScore(t *testing.T) {
 	scorer := NewScorer(DefaultConfig())
 
@@ -214,6 +353,32 @@ func TestCalculateIssuesScore(t *testing.T) {
 	}
 }
 
+func TestCalculateResponsivenessScore(t *testing.T) {
+	scorer := NewScorer(DefaultConfig())
+
+	tests := []struct {
+		name            string
+		recentIssues    int
+		medianResponse  time.Duration
+		medianClose     time.Duration
+		unansweredShare float64
+		want            float64
+	}{
+		{name: "no recent issues", recentIssues: 0, want: 1.0},
+		{name: "fast and complete", recentIssues: 20, medianResponse: 3 * time.Hour, medianClose: 2 * 24 * time.Hour, unansweredShare: 0, want: 1.0},
+		{name: "slow", recentIssues: 20, medianResponse: 10 * 24 * time.Hour, medianClose: 60 * 24 * time.Hour, unansweredShare: 0.5, want: (0.4 + 0.4 + 0.5) / 3.0},
+		{name: "ignored", recentIssues: 20, unansweredShare: 1.0, want: 0.0},
+		{name: "closed without comment", recentIssues: 5, medianClose: 24 * time.Hour, unansweredShare: 0, want: (0.0 + 1.0 + 1.0) / 3.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scorer.calculateResponsivenessScore(tt.recentIssues, tt.medianResponse, tt.medianClose, tt.unansweredShare)
+			require.InDelta(t, tt.want, got, 0.0001)
+		})
+	}
+}
+
 func TestCalculatePRsScore(t *testing.T) {
 	scorer := NewScorer(DefaultConfig())
 
@@ -238,6 +403,54 @@ func TestCalculatePRsScore(t *testing.T) {
 	}
 }
 
+func TestCalculatePRThroughputScore(t *testing.T) {
+	scorer := NewScorer(DefaultConfig())
+
+	tests := []struct {
+		name           string
+		merged         int
+		closedUnmerged int
+		medianMerge    time.Duration
+		want           float64
+	}{
+		{name: "no recent PRs", want: 1.0},
+		{name: "fast merges", merged: 9, closedUnmerged: 1, medianMerge: 6 * time.Hour, want: (0.9 + 1.0) / 2.0},
+		{name: "slow merges", merged: 5, closedUnmerged: 5, medianMerge: 14 * 24 * time.Hour, want: (0.5 + 0.5) / 2.0},
+		{name: "nothing merged", merged: 0, closedUnmerged: 4, want: 0.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scorer.calculatePRThroughputScore(tt.merged, tt.closedUnmerged, tt.medianMerge)
+			require.InDelta(t, tt.want, got, 0.0001)
+		})
+	}
+}
+
+func TestCalculatePRReviewsScore(t *testing.T) {
+	scorer := NewScorer(DefaultConfig())
+
+	tests := []struct {
+		name          string
+		merged        int
+		approvedShare float64
+		stalePRs      int
+		want          float64
+	}{
+		{name: "no merges, no stale PRs", want: 1.0},
+		{name: "all approved", merged: 10, approvedShare: 1.0, stalePRs: 0, want: 1.0},
+		{name: "half approved, some stale", merged: 10, approvedShare: 0.5, stalePRs: 9, want: (0.5 + 0.5) / 2.0},
+		{name: "many stale", merged: 0, stalePRs: 150, want: 0.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scorer.calculatePRReviewsScore(tt.merged, tt.approvedShare, tt.stalePRs)
+			require.InDelta(t, tt.want, got, 0.0001)
+		})
+	}
+}
+
 func TestCalculateReleaseFrequencyScore(t *testing.T) {
 	scorer := NewScorer(DefaultConfig())
 
@@ -262,3 +475,127 @@ func TestCalculateReleaseFrequencyScore(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateReleaseQualityScore(t *testing.T) {
+	scorer := NewScorer(DefaultConfig())
+
+	tests := []struct {
+		name            string
+		releaseCount    int
+		medianInterval  time.Duration
+		semverShare     float64
+		prereleaseShare float64
+		notesShare      float64
+		majorBumps      int
+		want            float64
+	}{
+		{name: "no releases", releaseCount: 0, want: 0.0},
+		{name: "monthly semver releases with notes", releaseCount: 30, medianInterval: 20 * 24 * time.Hour, semverShare: 1.0, notesShare: 1.0, majorBumps: 1, want: 1.0},
+		{name: "single release", releaseCount: 1, semverShare: 1.0, want: (0.0 + 1.0 + 1.0 + 0.0 + 1.0) / 5.0},
+		{name: "unstable API", releaseCount: 10, medianInterval: 60 * 24 * time.Hour, semverShare: 1.0, prereleaseShare: 0.5, notesShare: 0.5, majorBumps: 3, want: (0.8 + 1.0 + 0.5 + 0.5 + 0.0) / 5.0},
+		{name: "irregular untagged releases", releaseCount: 4, medianInterval: 300 * 24 * time.Hour, want: (0.2 + 0.0 + 1.0 + 0.0 + 1.0) / 5.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scorer.calculateReleaseQualityScore(tt.releaseCount, tt.medianInterval, tt.semverShare, tt.prereleaseShare, tt.notesShare, tt.majorBumps)
+			require.InDelta(t, tt.want, got, 0.0001)
+		})
+	}
+}
+
+func TestCalculateSecurityScore(t *testing.T) {
+	scorer := NewScorer(DefaultConfig())
+	yes, no := true, false
+
+	tests := []struct {
+		name                 string
+		branchProtected      *bool
+		requiredReviews      int
+		requiresStatusChecks bool
+		vulnerabilityAlerts  *bool
+		advisories           int
+		hasPolicy            bool
+		want                 float64
+	}{
+		{name: "nothing configured", branchProtected: &no, vulnerabilityAlerts: &no, want: (0.0 + 0.0 + 0.0 + 0.5) / 4.0},
+		{name: "fully hardened", branchProtected: &yes, requiredReviews: 2, requiresStatusChecks: true, vulnerabilityAlerts: &yes, advisories: 4, hasPolicy: true, want: 1.0},
+		{name: "protection without reviews", branchProtected: &yes, requiresStatusChecks: true, vulnerabilityAlerts: &no, hasPolicy: true, want: (0.75 + 0.0 + 1.0 + 0.5) / 4.0},
+		{name: "alerts only", branchProtected: &no, vulnerabilityAlerts: &yes, want: (0.0 + 1.0 + 0.0 + 0.5) / 4.0},
+		{name: "alerts hidden", branchProtected: &yes, requiredReviews: 1, hasPolicy: true, want: (0.75 + 1.0 + 0.5) / 3.0},
+		{name: "settings hidden", hasPolicy: true, want: (1.0 + 0.5) / 2.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scorer.calculateSecurityScore(tt.branchProtected, tt.requiredReviews, tt.requiresStatusChecks, tt.vulnerabilityAlerts, tt.advisories, tt.hasPolicy)
+			require.InDelta(t, tt.want, got, 0.0001)
+		})
+	}
+}
+
+func TestSecurityWeight(t *testing.T) {
+	require.Equal(t, 0.03, DefaultConfig().Weights.SecurityWeight())
+	require.Equal(t, 0.05, Weights{Security: 0.03, HasSecurity: 0.05}.SecurityWeight())
+}
+
+func TestCalculateMaturityScore(t *testing.T) {
+	scorer := NewScorer(DefaultConfig())
+
+	require.Equal(t, 0.0, scorer.calculateMaturityScore(time.Time{}))
+	require.InDelta(t, 0.5, scorer.calculateMaturityScore(time.Now().AddDate(0, 0, -547)), 0.01)
+	require.Equal(t, 1.0, scorer.calculateMaturityScore(time.Now().AddDate(-5, 0, 0)))
+}
+
+func TestCalculateDiscoverabilityScore(t *testing.T) {
+	scorer := NewScorer(DefaultConfig())
+
+	require.Equal(t, 0.0, scorer.calculateDiscoverabilityScore(nil, ""))
+	require.Equal(t, 0.5, scorer.calculateDiscoverabilityScore([]string{"cli"}, ""))
+	require.Equal(t, 1.0, scorer.calculateDiscoverabilityScore([]string{"cli"}, "https://example.com"))
+}
+
+func TestCalculateContributorsScore(t *testing.T) {
+	scorer := NewScorer(DefaultConfig())
+
+	tests := []struct {
+		name         string
+		contributors int
+		want         float64
+	}{
+		{name: "no contributors", contributors: 0, want: 0.0},
+		{name: "single contributor", contributors: 1, want: math.Log10(2) / 1.5}, // ~0.2007
+		{name: "small team", contributors: 9, want: 1.0 / 1.5},                   // ~0.6667
+		{name: "large team", contributors: 100, want: 1.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scorer.calculateContributorsScore(tt.contributors)
+			require.InDelta(t, tt.want, got, 0.0001)
+		})
+	}
+}
+
+func TestCalculateBusFactorScore(t *testing.T) {
+	scorer := NewScorer(DefaultConfig())
+
+	tests := []struct {
+		name      string
+		busFactor int
+		topShare  float64
+		want      float64
+	}{
+		{name: "no history", busFactor: 0, topShare: 0, want: 0.0},
+		{name: "single maintainer", busFactor: 1, topShare: 1.0, want: (0.25 + 0.0) / 2.0},
+		{name: "dominant maintainer", busFactor: 1, topShare: 0.6, want: (0.25 + 0.4) / 2.0},
+		{name: "spread out", busFactor: 5, topShare: 0.1, want: (1.0 + 0.9) / 2.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scorer.calculateBusFactorScore(tt.busFactor, tt.topShare)
+			require.InDelta(t, tt.want, got, 0.0001)
+		})
+	}
+}
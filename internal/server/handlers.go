@@ -2,18 +2,30 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/kdimtriCP/gh-inspector/internal/formatter"
+	"github.com/kdimtriCP/gh-inspector/internal/github"
 )
 
+// maxRepositories is the most repositories one request may score, search
+// results included.
+const maxRepositories = 50
+
 // ScoreRequest represents the request body for scoring repositories
 // @Description Request body for scoring GitHub repositories
 type ScoreRequest struct {
-	// List of repositories in owner/name format
+	// List of repositories as owner/name, repository URLs or git@host:owner/name
 	// @example ["kubernetes/kubernetes", "golang/go"]
 	Repositories []string `json:"repositories" example:"kubernetes/kubernetes,golang/go"`
+	// GitHub repository search whose results are scored along with the listed repositories (optional)
+	// @example topic:http-router language:go stars:>500
+	Search string `json:"search,omitempty" example:"topic:http-router language:go stars:>500"`
+	// Most search results to score (optional, defaults to the server's search limit, lowered to fit). Together with the listed repositories it may not exceed 50
+	// @example 20
+	Limit int `json:"limit,omitempty" example:"20"`
 	// Output format (optional)
 	// @example json
 	OutputFormat string `json:"output_format,omitempty" example:"json"`
@@ -32,6 +44,10 @@ type ScoreResponse struct {
 	SuccessCount int `json:"success_count" example:"2"`
 	// Number of failed scorings
 	ErrorCount int `json:"error_count" example:"0"`
+	// Repositories that could not be scored and why
+	Errors []*formatter.ErrorRecord `json:"errors"`
+	// Stale repository references, such as renamed or transferred repositories
+	Warnings []string `json:"warnings" example:"kubernetes/kubernetes-old has moved to kubernetes/kubernetes"`
 }
 
 // HealthResponse represents the health check response
@@ -56,13 +72,14 @@ type ErrorResponse struct {
 
 // handleScore godoc
 // @Summary Score GitHub repositories
-// @Description Analyzes and scores a list of GitHub repositories based on various metrics
+// @Description Analyzes and scores a list of GitHub repositories, and the results of a repository search, based on various metrics
 // @Tags analysis
 // @Accept json
 // @Produce json
 // @Param request body ScoreRequest true "List of repositories to analyze"
 // @Success 200 {object} ScoreResponse "Successfully scored repositories"
 // @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 502 {object} ErrorResponse "Repository search failed"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /api/v1/score [post]
 func (s *Server) handleScore(w http.ResponseWriter, r *http.Request) {
@@ -72,36 +89,56 @@ func (s *Server) handleScore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(req.Repositories) == 0 {
+	if len(req.Repositories) == 0 && req.Search == "" {
 		writeError(w, http.StatusBadRequest, "No repositories provided", "NO_REPOSITORIES")
 		return
 	}
 
-	if len(req.Repositories) > 50 {
-		writeError(w, http.StatusBadRequest, "Too many repositories (max 50)", "TOO_MANY_REPOSITORIES")
+	if len(req.Repositories) > maxRepositories {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Too many repositories (max %d)", maxRepositories), "TOO_MANY_REPOSITORIES")
 		return
 	}
 
+	repositories := req.Repositories
+	if req.Search != "" {
+		found, ok := s.search(w, r, req)
+		if !ok {
+			return
+		}
+		repositories = append(repositories, found...)
+	}
+	repositories = github.NormalizeRepositories(repositories)
+
 	response := &ScoreResponse{
 		Repositories: make([]*formatter.Record, 0),
+		Errors:       make([]*formatter.ErrorRecord, 0),
+		Warnings:     make([]string, 0),
 		Timestamp:    time.Now(),
-		TotalCount:   len(req.Repositories),
+		TotalCount:   len(repositories),
 	}
 
-	for _, repoName := range req.Repositories {
-		start := time.Now()
-		metricsData, err := s.analyzer.Analyze(r.Context(), repoName)
-		duration := time.Since(start)
-		if err != nil {
+	results := github.AnalyzeAll(r.Context(), s.analyzer, repositories, s.config.Concurrency)
+	for _, result := range results {
+		if result.Err != nil {
 			response.ErrorCount++
-			s.metricsRecorder.RecordRepositoryAnalysis("error", duration)
+			response.Errors = append(response.Errors, &formatter.ErrorRecord{
+				Repository: result.Repository,
+				Code:       string(github.ErrorCodeOf(result.Err)),
+				Message:    result.Err.Error(),
+			})
+			s.metricsRecorder.RecordRepositoryAnalysis("error", result.Duration)
 			continue
 		}
 
-		record := formatter.MetricsToRecord(metricsData)
+		if result.Metrics.MovedFrom != "" {
+			response.Warnings = append(response.Warnings,
+				fmt.Sprintf("%s has moved to %s", result.Metrics.MovedFrom, result.Metrics.FullName()))
+		}
+
+		record := formatter.MetricsToRecord(result.Metrics)
 		response.Repositories = append(response.Repositories, record)
 		response.SuccessCount++
-		s.metricsRecorder.RecordRepositoryAnalysis("success", duration)
+		s.metricsRecorder.RecordRepositoryAnalysis("success", result.Duration)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -110,6 +147,44 @@ func (s *Server) handleScore(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// search runs the request's repository search, writing an error response and
+// returning false when it cannot.
+func (s *Server) search(w http.ResponseWriter, r *http.Request, req ScoreRequest) ([]string, bool) {
+	searcher, ok := s.analyzer.(github.Searcher)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Search is not supported", "SEARCH_UNSUPPORTED")
+		return nil, false
+	}
+
+	// Search results count against maxRepositories, so the search is never
+	// asked for more than the listed repositories leave room for.
+	room := maxRepositories - len(req.Repositories)
+	limit := req.Limit
+	if limit == 0 {
+		limit = min(s.config.SearchLimit, room)
+	}
+	if room < 1 || limit > room {
+		writeError(w, http.StatusBadRequest,
+			fmt.Sprintf("Too many repositories including search results (max %d)", maxRepositories), "TOO_MANY_REPOSITORIES")
+		return nil, false
+	}
+	if limit < 1 || limit > s.config.MaxSearchLimit {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Search limit must be between 1 and %d", s.config.MaxSearchLimit), "INVALID_LIMIT")
+		return nil, false
+	}
+
+	found, err := searcher.SearchRepositories(r.Context(), req.Search, limit)
+	if err != nil {
+		status := http.StatusBadGateway
+		if github.ErrorCodeOf(err) == github.CodeInvalidName {
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, err.Error(), "SEARCH_FAILED")
+		return nil, false
+	}
+	return found, true
+}
+
 // handleHealth godoc
 // @Summary Health check
 // @Description Returns the health status of the service
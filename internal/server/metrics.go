@@ -56,6 +56,73 @@ var (
 			Help: "Total number of cache misses",
 		},
 	)
+
+	githubRateLimitRemaining = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "gh_inspector_github_rate_limit_remaining",
+			Help: "Remaining GitHub GraphQL rate limit points",
+		},
+	)
+
+	githubRateLimitLimit = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "gh_inspector_github_rate_limit_limit",
+			Help: "GitHub GraphQL rate limit points per window",
+		},
+	)
+
+	githubRateLimitReset = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "gh_inspector_github_rate_limit_reset_timestamp_seconds",
+			Help: "Unix time at which the GitHub GraphQL rate limit window resets",
+		},
+	)
+
+	githubRateLimitCost = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "gh_inspector_github_rate_limit_cost_total",
+			Help: "Total GitHub GraphQL rate limit points spent",
+		},
+	)
+
+	githubRateLimitWait = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "gh_inspector_github_rate_limit_wait_seconds_total",
+			Help: "Total time spent throttling GitHub queries in seconds",
+		},
+	)
+
+	githubRetriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gh_inspector_github_retries_total",
+			Help: "Total number of retried GitHub queries",
+		},
+		[]string{"reason"},
+	)
+
+	githubTokenRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gh_inspector_github_token_requests_total",
+			Help: "Total number of GitHub requests per pooled token",
+		},
+		[]string{"token"},
+	)
+
+	githubTokenRateLimitRemaining = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "gh_inspector_github_token_rate_limit_remaining",
+			Help: "Remaining GitHub rate limit points per pooled token",
+		},
+		[]string{"token"},
+	)
+
+	githubTokenAuthFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gh_inspector_github_token_auth_failures_total",
+			Help: "Total number of authentication failures per pooled token",
+		},
+		[]string{"token"},
+	)
 )
 
 type MetricsRecorder struct{}
@@ -87,6 +154,36 @@ func (m *MetricsRecorder) RecordCacheMiss() {
 	cacheMisses.Inc()
 }
 
+func (m *MetricsRecorder) RecordRateLimit(remaining, limit int, resetAt time.Time) {
+	githubRateLimitRemaining.Set(float64(remaining))
+	githubRateLimitLimit.Set(float64(limit))
+	githubRateLimitReset.Set(float64(resetAt.Unix()))
+}
+
+func (m *MetricsRecorder) RecordRateLimitCost(cost int) {
+	githubRateLimitCost.Add(float64(cost))
+}
+
+func (m *MetricsRecorder) RecordRateLimitWait(duration time.Duration) {
+	githubRateLimitWait.Add(duration.Seconds())
+}
+
+func (m *MetricsRecorder) RecordRetry(reason string) {
+	githubRetriesTotal.WithLabelValues(reason).Inc()
+}
+
+func (m *MetricsRecorder) RecordTokenRequest(token string) {
+	githubTokenRequests.WithLabelValues(token).Inc()
+}
+
+func (m *MetricsRecorder) RecordTokenRateLimit(token string, remaining int) {
+	githubTokenRateLimitRemaining.WithLabelValues(token).Set(float64(remaining))
+}
+
+func (m *MetricsRecorder) RecordTokenAuthFailure(token string) {
+	githubTokenAuthFailures.WithLabelValues(token).Inc()
+}
+
 func recordHTTPRequest(method, endpoint, status string) {
 	httpRequestsTotal.WithLabelValues(method, endpoint, status).Inc()
 }
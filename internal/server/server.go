@@ -31,14 +31,22 @@ type Config struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
+	Concurrency  int
+	// Repositories scored for a search without a limit, and the largest
+	// limit a request may ask for.
+	SearchLimit    int
+	MaxSearchLimit int
 }
 
 func DefaultConfig() *Config {
 	return &Config{
-		Port:         8080,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		Port:           8080,
+		ReadTimeout:    15 * time.Second,
+		WriteTimeout:   15 * time.Second,
+		IdleTimeout:    60 * time.Second,
+		Concurrency:    github.DefaultConcurrency,
+		SearchLimit:    github.DefaultSearchLimit,
+		MaxSearchLimit: github.DefaultMaxSearchLimit,
 	}
 }
 
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -12,6 +14,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kdimtriCP/gh-inspector/internal/github"
 	"github.com/kdimtriCP/gh-inspector/internal/metrics"
 	"github.com/kdimtriCP/gh-inspector/internal/mock/mock_github"
 )
@@ -107,6 +110,41 @@ func TestScoreEndpoint(t *testing.T) {
 		require.Equal(t, 0, response.ErrorCount)
 	})
 
+	t.Run("batched scoring", func(t *testing.T) {
+		batchAnalyzer := mock_github.NewMockBatchAnalyzer(ctrl)
+		batchSrv := New(batchAnalyzer, nil)
+
+		batchAnalyzer.EXPECT().BatchSize().Return(20).AnyTimes()
+		batchAnalyzer.EXPECT().
+			AnalyzeBatch(gomock.Any(), []string{"test/repo1", "test/missing"}).
+			Return(
+				[]*metrics.Repository{{Owner: "test", Name: "repo1", MovedFrom: "test/old-repo1", Score: 85.5}, nil},
+				[]error{nil, &github.Error{Code: github.CodeNotFound, Repository: "test/missing", Err: errors.New("not found")}},
+			)
+
+		body, _ := json.Marshal(ScoreRequest{Repositories: []string{"test/repo1", "test/missing"}})
+		req := httptest.NewRequest("POST", "/api/v1/score", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+
+		batchSrv.router.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var response ScoreResponse
+		err := json.NewDecoder(rr.Body).Decode(&response)
+		require.NoError(t, err)
+		require.Len(t, response.Repositories, 1)
+		require.Equal(t, 1, response.SuccessCount)
+		require.Equal(t, 1, response.ErrorCount)
+		require.Len(t, response.Errors, 1)
+		require.Equal(t, "test/missing", response.Errors[0].Repository)
+		require.Equal(t, "NOT_FOUND", response.Errors[0].Code)
+		require.Equal(t, "not found", response.Errors[0].Message)
+		require.Equal(t, []string{"test/old-repo1 has moved to test/repo1"}, response.Warnings)
+		require.Equal(t, "test/old-repo1", response.Repositories[0].MovedFrom)
+	})
+
 	t.Run("empty repositories", func(t *testing.T) {
 		reqBody := ScoreRequest{
 			Repositories: []string{},
@@ -171,6 +209,115 @@ func TestScoreEndpoint(t *testing.T) {
 	})
 }
 
+func TestScoreEndpointSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type searchAnalyzer struct {
+		*mock_github.MockAnalyzer
+		*mock_github.MockSearcher
+	}
+	analyzer := searchAnalyzer{mock_github.NewMockAnalyzer(ctrl), mock_github.NewMockSearcher(ctrl)}
+	srv := New(analyzer, nil)
+
+	post := func(t *testing.T, server *Server, req ScoreRequest) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(req)
+		httpReq := httptest.NewRequest("POST", "/api/v1/score", bytes.NewReader(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		server.router.ServeHTTP(rr, httpReq)
+		return rr
+	}
+
+	t.Run("search results scored with listed repositories", func(t *testing.T) {
+		analyzer.MockSearcher.EXPECT().
+			SearchRepositories(gomock.Any(), "topic:http-router", DefaultConfig().SearchLimit).
+			Return([]string{"test/router", "test/listed"}, nil)
+		for _, name := range []string{"listed", "router"} {
+			analyzer.MockAnalyzer.EXPECT().
+				Analyze(gomock.Any(), "test/"+name).
+				Return(&metrics.Repository{Owner: "test", Name: name}, nil)
+		}
+
+		rr := post(t, srv, ScoreRequest{Repositories: []string{"test/listed"}, Search: "topic:http-router"})
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var response ScoreResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+		require.Equal(t, 2, response.TotalCount)
+		require.Equal(t, "test/listed", response.Repositories[0].Repository)
+		require.Equal(t, "test/router", response.Repositories[1].Repository)
+	})
+
+	t.Run("too many repositories with search results", func(t *testing.T) {
+		var listed []string
+		for i := 0; i < 30; i++ {
+			listed = append(listed, fmt.Sprintf("test/listed%d", i))
+		}
+
+		rr := post(t, srv, ScoreRequest{Repositories: listed, Search: "language:go", Limit: 30})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+
+		var response ErrorResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+		require.Equal(t, "TOO_MANY_REPOSITORIES", response.Code)
+
+		rr = post(t, srv, ScoreRequest{Search: "language:go", Limit: 51})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+		require.Equal(t, "TOO_MANY_REPOSITORIES", response.Code)
+	})
+
+	t.Run("default limit lowered to fit", func(t *testing.T) {
+		var listed []string
+		for i := 0; i < 40; i++ {
+			listed = append(listed, fmt.Sprintf("test/listed%d", i))
+			analyzer.MockAnalyzer.EXPECT().
+				Analyze(gomock.Any(), listed[i]).
+				Return(&metrics.Repository{Owner: "test", Name: fmt.Sprintf("listed%d", i)}, nil)
+		}
+		analyzer.MockSearcher.EXPECT().
+			SearchRepositories(gomock.Any(), "language:go", 10).
+			Return(nil, nil)
+
+		rr := post(t, srv, ScoreRequest{Repositories: listed, Search: "language:go"})
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("limit above the maximum", func(t *testing.T) {
+		srv := New(analyzer, &Config{Concurrency: 1, SearchLimit: 5, MaxSearchLimit: 20})
+		rr := post(t, srv, ScoreRequest{Search: "language:go", Limit: 21})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+
+		var response ErrorResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+		require.Equal(t, "INVALID_LIMIT", response.Code)
+		require.Equal(t, "Search limit must be between 1 and 20", response.Error)
+	})
+
+	t.Run("search failure", func(t *testing.T) {
+		analyzer.MockSearcher.EXPECT().
+			SearchRepositories(gomock.Any(), "language:go", 5).
+			Return(nil, &github.Error{Code: github.CodeUpstreamFailure, Err: errors.New("bad gateway")})
+
+		rr := post(t, srv, ScoreRequest{Search: "language:go", Limit: 5})
+		require.Equal(t, http.StatusBadGateway, rr.Code)
+
+		var response ErrorResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+		require.Equal(t, "SEARCH_FAILED", response.Code)
+	})
+
+	t.Run("analyzer without search", func(t *testing.T) {
+		rr := post(t, New(mock_github.NewMockAnalyzer(ctrl), nil), ScoreRequest{Search: "language:go"})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+
+		var response ErrorResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+		require.Equal(t, "SEARCH_UNSUPPORTED", response.Code)
+	})
+}
+
 func TestCORSMiddleware(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
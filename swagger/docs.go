@@ -50,7 +50,7 @@ const docTemplate = `{
         },
         "/api/v1/score": {
             "post": {
-                "description": "Analyzes and scores a list of GitHub repositories based on various metrics",
+                "description": "Analyzes and scores a list of GitHub repositories, and the results of a repository search, based on various metrics",
                 "consumes": [
                     "application/json"
                 ],
@@ -90,6 +90,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/server.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Repository search failed",
+                        "schema": {
+                            "$ref": "#/definitions/server.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -116,10 +122,50 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "formatter.ErrorRecord": {
+            "description": "Repository scoring failure",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Error code",
+                    "type": "string",
+                    "enum": [
+                        "NOT_FOUND",
+                        "ACCESS_DENIED",
+                        "RATE_LIMITED",
+                        "INVALID_NAME",
+                        "UPSTREAM_FAILURE",
+                        "TIMEOUT",
+                        "CANCELED"
+                    ],
+                    "example": "NOT_FOUND"
+                },
+                "message": {
+                    "description": "Error message",
+                    "type": "string",
+                    "example": "Could not resolve to a Repository with the name 'kubernetes/no-such-repo'."
+                },
+                "repository": {
+                    "description": "Repository as given in the request",
+                    "type": "string",
+                    "example": "kubernetes/no-such-repo"
+                }
+            }
+        },
         "formatter.Record": {
             "description": "Repository scoring results",
             "type": "object",
             "properties": {
+                "age_days": {
+                    "description": "Days since the repository was created, -1 when unknown",
+                    "type": "integer",
+                    "example": 4100
+                },
+                "approved_merge_share": {
+                    "description": "Share of recently merged pull requests with an approving review (0-1)",
+                    "type": "number",
+                    "example": 0.9
+                },
                 "archived": {
                     "description": "Archive status",
                     "type": "string",
@@ -129,6 +175,26 @@ const docTemplate = `{
                     ],
                     "example": "No"
                 },
+                "bot_workflows": {
+                    "description": "GitHub Actions workflows that triage issues and pull requests or run on a schedule",
+                    "type": "integer",
+                    "example": 2
+                },
+                "branch_protected": {
+                    "description": "Branch protection or rulesets on the default branch",
+                    "type": "string",
+                    "enum": [
+                        "Yes",
+                        "No",
+                        "Unknown"
+                    ],
+                    "example": "Yes"
+                },
+                "bus_factor": {
+                    "description": "Fewest authors that made at least half of the commits in the history window",
+                    "type": "integer",
+                    "example": 9
+                },
                 "ci_cd": {
                     "description": "CI/CD presence",
                     "type": "string",
@@ -138,6 +204,21 @@ const docTemplate = `{
                     ],
                     "example": "Yes"
                 },
+                "ci_providers": {
+                    "description": "CI services running builds or tests",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "GitHub Actions"
+                    ]
+                },
+                "closed_unmerged_prs": {
+                    "description": "Pull requests closed without merging in the history window",
+                    "type": "integer",
+                    "example": 25
+                },
                 "code_of_conduct": {
                     "description": "Code of conduct presence",
                     "type": "string",
@@ -147,6 +228,22 @@ const docTemplate = `{
                     ],
                     "example": "Yes"
                 },
+                "codeql": {
+                    "description": "CodeQL code scanning presence",
+                    "type": "string",
+                    "enum": [
+                        "Yes",
+                        "No"
+                    ],
+                    "example": "Yes"
+                },
+                "community_files": {
+                    "description": "Path of the file satisfying each community health check found in the root, .github or docs",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "contributing": {
                     "description": "Contributing guide presence",
                     "type": "string",
@@ -156,21 +253,71 @@ const docTemplate = `{
                     ],
                     "example": "Yes"
                 },
+                "contributors": {
+                    "description": "Distinct commit authors in the history window",
+                    "type": "integer",
+                    "example": 120
+                },
+                "created_at": {
+                    "description": "Creation date",
+                    "type": "string",
+                    "example": "2014-06-06"
+                },
+                "default_branch": {
+                    "description": "Default branch name",
+                    "type": "string",
+                    "example": "master"
+                },
+                "dependabot": {
+                    "description": "Dependabot version updates configuration presence",
+                    "type": "string",
+                    "enum": [
+                        "Yes",
+                        "No"
+                    ],
+                    "example": "Yes"
+                },
                 "description": {
                     "description": "Repository description",
                     "type": "string",
                     "example": "Production-Grade Container Scheduling and Management"
                 },
+                "disk_usage_kb": {
+                    "description": "Repository size on disk in kilobytes",
+                    "type": "integer",
+                    "example": 1200000
+                },
+                "fork": {
+                    "description": "Fork status",
+                    "type": "string",
+                    "enum": [
+                        "Yes",
+                        "No"
+                    ],
+                    "example": "No"
+                },
                 "forks": {
                     "description": "Number of forks",
                     "type": "integer",
                     "example": 39000
                 },
+                "homepage": {
+                    "description": "Homepage URL",
+                    "type": "string",
+                    "example": "https://kubernetes.io"
+                },
                 "language": {
                     "description": "Primary programming language",
                     "type": "string",
                     "example": "Go"
                 },
+                "languages": {
+                    "description": "Languages by size in bytes, largest first, at most 20",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/metrics.LanguageSize"
+                    }
+                },
                 "last_commit": {
                     "description": "Last commit relative time",
                     "type": "string",
@@ -190,6 +337,63 @@ const docTemplate = `{
                     ],
                     "example": "Yes"
                 },
+                "license_class": {
+                    "description": "License family",
+                    "type": "string",
+                    "enum": [
+                        "permissive",
+                        "weak-copyleft",
+                        "strong-copyleft",
+                        "unknown",
+                        "none"
+                    ],
+                    "example": "permissive"
+                },
+                "license_id": {
+                    "description": "SPDX identifier of the license, NOASSERTION when GitHub could not identify it",
+                    "type": "string",
+                    "example": "Apache-2.0"
+                },
+                "license_violation": {
+                    "description": "Why the license violates the configured license policy",
+                    "type": "string",
+                    "example": "license AGPL-3.0 (strong-copyleft) is denied"
+                },
+                "median_issue_close_hours": {
+                    "description": "Median hours from opening to closing, for recent issues",
+                    "type": "number",
+                    "example": 96
+                },
+                "median_issue_response_hours": {
+                    "description": "Median hours from opening to the first maintainer comment, for recent issues",
+                    "type": "number",
+                    "example": 5.5
+                },
+                "median_pr_merge_hours": {
+                    "description": "Median hours from opening to merge, for recently merged pull requests",
+                    "type": "number",
+                    "example": 30
+                },
+                "median_release_interval_days": {
+                    "description": "Median days between consecutive releases, over the last 10 releases",
+                    "type": "number",
+                    "example": 21
+                },
+                "merge_ratio": {
+                    "description": "Share of closed pull requests in the history window that were merged (0-1)",
+                    "type": "number",
+                    "example": 0.85
+                },
+                "merged_prs": {
+                    "description": "Pull requests merged in the history window",
+                    "type": "integer",
+                    "example": 140
+                },
+                "moved_from": {
+                    "description": "Name the repository was requested under before it was renamed or transferred",
+                    "type": "string",
+                    "example": "kubernetes/kubernetes-old"
+                },
                 "open_issues": {
                     "description": "Number of open issues",
                     "type": "integer",
@@ -200,6 +404,16 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 300
                 },
+                "parent": {
+                    "description": "Repository this one was forked from",
+                    "type": "string",
+                    "example": "kubernetes/kubernetes"
+                },
+                "prerelease_share": {
+                    "description": "Share of the last 10 releases marked as pre-releases (0-1)",
+                    "type": "number",
+                    "example": 0.3
+                },
                 "readme": {
                     "description": "README presence",
                     "type": "string",
@@ -209,16 +423,66 @@ const docTemplate = `{
                     ],
                     "example": "Yes"
                 },
+                "recent_issues": {
+                    "description": "Issues opened in the history window",
+                    "type": "integer",
+                    "example": 85
+                },
+                "recent_major_bumps": {
+                    "description": "Major version bumps among the last 10 releases within the past year",
+                    "type": "integer",
+                    "example": 0
+                },
+                "release_notes_share": {
+                    "description": "Share of the last 10 releases with release notes (0-1)",
+                    "type": "number",
+                    "example": 1
+                },
+                "release_workflows": {
+                    "description": "GitHub Actions workflows that only run for releases or tags",
+                    "type": "integer",
+                    "example": 1
+                },
                 "releases": {
                     "description": "Number of releases",
                     "type": "integer",
                     "example": 350
                 },
+                "renovate": {
+                    "description": "Renovate configuration presence",
+                    "type": "string",
+                    "enum": [
+                        "Yes",
+                        "No"
+                    ],
+                    "example": "No"
+                },
                 "repository": {
                     "description": "Repository name in owner/name format",
                     "type": "string",
                     "example": "kubernetes/kubernetes"
                 },
+                "required_reviews": {
+                    "description": "Approving reviews the default branch rulesets require before merging",
+                    "type": "integer",
+                    "example": 1
+                },
+                "required_status_checks": {
+                    "description": "Whether status checks must pass before merging into the default branch",
+                    "type": "string",
+                    "enum": [
+                        "Yes",
+                        "No"
+                    ],
+                    "example": "Yes"
+                },
+                "sbom_components": {
+                    "description": "SBOM entries that resolved to the repository, when scoring an SBOM",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/metrics.SBOMComponent"
+                    }
+                },
                 "score": {
                     "description": "Repository score (0-100)",
                     "type": "number",
@@ -233,15 +497,119 @@ const docTemplate = `{
                     ],
                     "example": "Yes"
                 },
+                "security_advisories": {
+                    "description": "Published security advisories, counting at most 100",
+                    "type": "integer",
+                    "example": 12
+                },
+                "semver_release_share": {
+                    "description": "Share of the last 10 releases tagged with a semantic version (0-1)",
+                    "type": "number",
+                    "example": 1
+                },
+                "stale_prs": {
+                    "description": "Open pull requests without updates for 30 days",
+                    "type": "integer",
+                    "example": 12
+                },
                 "stars": {
                     "description": "Number of stars",
                     "type": "integer",
                     "example": 108000
                 },
+                "template": {
+                    "description": "Template repository status",
+                    "type": "string",
+                    "enum": [
+                        "Yes",
+                        "No"
+                    ],
+                    "example": "No"
+                },
+                "test_workflows": {
+                    "description": "GitHub Actions workflows that build or test on pushes and pull requests",
+                    "type": "integer",
+                    "example": 3
+                },
+                "top_contributor_share": {
+                    "description": "Share of commits in the history window made by the most active author (0-1)",
+                    "type": "number",
+                    "example": 0.12
+                },
+                "topics": {
+                    "description": "Repository topics",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "kubernetes",
+                        "containers"
+                    ]
+                },
+                "unanswered_issue_share": {
+                    "description": "Share of recent issues still open without a maintainer comment (0-1)",
+                    "type": "number",
+                    "example": 0.1
+                },
+                "vulnerability_alerts": {
+                    "description": "Dependabot vulnerability alerts, only visible to repository admins",
+                    "type": "string",
+                    "enum": [
+                        "Yes",
+                        "No",
+                        "Unknown"
+                    ],
+                    "example": "Yes"
+                },
                 "watchers": {
                     "description": "Number of watchers",
                     "type": "integer",
                     "example": 3500
+                },
+                "weekly_commits": {
+                    "description": "Default-branch commits per week over the past year, oldest first",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        12,
+                        9,
+                        15,
+                        20
+                    ]
+                }
+            }
+        },
+        "metrics.LanguageSize": {
+            "type": "object",
+            "properties": {
+                "bytes": {
+                    "type": "integer",
+                    "example": 1048576
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Go"
+                }
+            }
+        },
+        "metrics.SBOMComponent": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "github.com/go-chi/chi/v5"
+                },
+                "ref": {
+                    "description": "CycloneDX bom-ref or SPDX identifier",
+                    "type": "string",
+                    "example": "pkg:golang/github.com/go-chi/chi/v5@v5.2.2"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "v5.2.2"
                 }
             }
         },
@@ -286,13 +654,18 @@ const docTemplate = `{
             "description": "Request body for scoring GitHub repositories",
             "type": "object",
             "properties": {
+                "limit": {
+                    "description": "Most search results to score (optional, defaults to the server's search limit, lowered to fit). Together with the listed repositories it may not exceed 50\n@example 20",
+                    "type": "integer",
+                    "example": 20
+                },
                 "output_format": {
                     "description": "Output format (optional)\n@example json",
                     "type": "string",
                     "example": "json"
                 },
                 "repositories": {
-                    "description": "List of repositories in owner/name format\n@example [\"kubernetes/kubernetes\", \"golang/go\"]",
+                    "description": "List of repositories as owner/name, repository URLs or git@host:owner/name\n@example [\"kubernetes/kubernetes\", \"golang/go\"]",
                     "type": "array",
                     "items": {
                         "type": "string"
@@ -301,6 +674,11 @@ const docTemplate = `{
                         "kubernetes/kubernetes",
                         "golang/go"
                     ]
+                },
+                "search": {
+                    "description": "GitHub repository search whose results are scored along with the listed repositories (optional)\n@example topic:http-router language:go stars:\u003e500",
+                    "type": "string",
+                    "example": "topic:http-router language:go stars:\u003e500"
                 }
             }
         },
@@ -313,6 +691,13 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 0
                 },
+                "errors": {
+                    "description": "Repositories that could not be scored and why",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/formatter.ErrorRecord"
+                    }
+                },
                 "repositories": {
                     "description": "List of scored repositories",
                     "type": "array",
@@ -334,6 +719,16 @@ const docTemplate = `{
                     "description": "Total number of repositories requested",
                     "type": "integer",
                     "example": 2
+                },
+                "warnings": {
+                    "description": "Stale repository references, such as renamed or transferred repositories",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "kubernetes/kubernetes-old has moved to kubernetes/kubernetes"
+                    ]
                 }
             }
         }